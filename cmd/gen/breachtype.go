@@ -2,6 +2,7 @@ package gen
 
 import (
 	"bytes"
+	"go/format"
 	"log"
 	"os"
 	"path/filepath"
@@ -22,6 +23,39 @@ func BreachType(breachTypes []string) {
 	for _, bt := range breachTypes {
 		appendFileContent(breachTypeFullFilePath, breachTypeFuncs(bt))
 	}
+	appendFileContent(breachTypeFullFilePath, breachTypeFactories(breachTypes))
+
+	// Align the generated map.
+	content, err := format.Source([]byte(strings.Join(getFileLines(breachTypeFullFilePath), "\n")))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	writeFileContent(breachTypeFullFilePath, string(content))
+}
+
+// breachTypeFactories registers the breach types, so that breaches can be
+// decoded from JSON based on their type.
+func breachTypeFactories(breachTypes []string) string {
+	tmplStr := `
+// breachFactories create an empty breach for each breach type, in which
+// the JSON representation of a breach can be decoded.
+var breachFactories = map[BreachType]func() Breach{
+{{- range .}}
+	BreachType{{.}}: func() Breach { return &{{.}}Breach{} },
+{{- end}}
+}
+`
+	tmpl, err := template.New("breachTypeFactories").Parse(tmplStr)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = tmpl.Execute(buf, breachTypes)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return buf.String()
 }
 
 func breachTypeFuncs(bt string) string {
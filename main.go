@@ -2,20 +2,19 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
-	"text/tabwriter"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/diff"
+	"github.com/salsadigitalauorg/shipshape/pkg/history"
 	"github.com/salsadigitalauorg/shipshape/pkg/lagoon"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
 	"github.com/salsadigitalauorg/shipshape/pkg/shipshape"
@@ -38,11 +37,15 @@ var (
 	// selfUpdate     bool
 
 	errorCodeOnFailure bool
-	projectDir         string
+	projectDirs        []string
 	checksFiles        []string
 	checkTypesToRun    []string
 	excludeDb          bool
-	outputFormat       string
+	failSeverity       string
+	recordHistory      bool
+	historyDir         string
+	outputFormats      []string
+	templateFile       string
 	remediate          bool
 	logLevel           string
 	verbose            bool
@@ -62,28 +65,68 @@ func main() {
 		os.Exit(0)
 	}
 
+	switch pflag.Arg(0) {
+	case "diff":
+		runDiff()
+	case "merge":
+		runMerge()
+	case "history", "trend":
+		if pflag.NArg() > 1 {
+			log.Fatalf("no argument expected for '%s', got '%+v'", pflag.Arg(0), pflag.Args()[1:])
+		}
+		records, err := history.Load(historyDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if pflag.Arg(0) == "history" {
+			history.DisplayList(os.Stdout, records)
+		} else {
+			history.DisplayTrend(os.Stdout, records)
+		}
+		os.Exit(0)
+	}
+
 	if listChecks {
-		fmt.Println("Type of checks available:")
-		checks := []string{}
-		for c := range config.ChecksRegistry {
-			checks = append(checks, string(c))
+		determineLogLevel()
+		if lvl, err := log.ParseLevel(logLevel); err == nil {
+			log.SetLevel(lvl)
+		}
+		if missingChecksFile() == "" {
+			if err := shipshape.RegisterConfigPlugins(checksFiles); err != nil {
+				log.WithError(err).Warn("could not register the plugins of the config")
+			}
 		}
-		sort.Strings(checks)
-		for _, c := range checks {
-			fmt.Println("  - " + c)
+		fmt.Println("Type of checks available:")
+		for _, ct := range config.ChecksRegistry.Types() {
+			meta, _ := config.ChecksRegistry.Meta(ct)
+			line := "  - " + string(ct)
+			if meta.Description != "" {
+				line += ": " + meta.Description
+			}
+			if meta.RequiresDb {
+				line += " [requires database]"
+			}
+			if meta.SupportsRemediation {
+				line += " [supports remediation]"
+			}
+			fmt.Println(line)
+			if len(meta.Fields) > 0 {
+				fmt.Println("      fields: " + strings.Join(meta.Fields, ", "))
+			}
 		}
 		os.Exit(0)
 	}
 
 	parseArgs()
-	if !isValidOutputFormat(&outputFormat) {
-		log.Fatalf("Invalid output format; needs to be one of: %s.", strings.Join(shipshape.OutputFormats, "|"))
+	outputs, err := shipshape.ParseOutputs(outputFormats, templateFile)
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	determineLogLevel()
 
 	// simple check to ensure we have everything we need to write to the API if required.
-	if lagoon.PushProblemsToInsightRemote {
+	if lagoon.PushProblemsToInsightRemote || lagoon.PushFacts {
 		if lagoonApiBaseUrl == "" {
 			log.Fatal("lagoon api base url not provided")
 		}
@@ -92,32 +135,58 @@ func main() {
 		}
 	}
 
-	for _, f := range checksFiles {
-		if !utils.StringIsUrl(f) {
-			if _, err := os.Stat(f); os.IsNotExist(err) {
-				fmt.Fprintf(os.Stderr, "checks file '%s' not found\n", f)
-
-				if errorCodeOnFailure {
-					os.Exit(1)
-				}
-				os.Exit(0)
-			}
+	if f := missingChecksFile(); f != "" {
+		fmt.Fprintf(os.Stderr, "checks file '%s' not found\n", f)
+
+		if errorCodeOnFailure {
+			os.Exit(1)
 		}
+		os.Exit(0)
 	}
 
-	err := shipshape.Init(
-		projectDir,
-		checksFiles,
-		checkTypesToRun,
-		excludeDb,
-		remediate,
-		logLevel,
-		lagoonApiBaseUrl,
-		lagoonApiToken)
-	if err != nil {
+	if failSeverity != "" && !config.IsValidSeverity(config.Severity(failSeverity)) {
+		log.Fatalf("invalid fail severity '%s'; needs to be one of: %s",
+			failSeverity, strings.Join(severityNames(), "|"))
+	}
+
+	initRun := func(projectDir string) error {
+		err := shipshape.Init(
+			projectDir,
+			checksFiles,
+			checkTypesToRun,
+			excludeDb,
+			remediate,
+			logLevel,
+			lagoonApiBaseUrl,
+			lagoonApiToken)
+		if err != nil {
+			return err
+		}
+		if failSeverity != "" {
+			shipshape.RunConfig.FailSeverity = config.Severity(failSeverity)
+		}
+		return nil
+	}
+
+	firstDir := ""
+	if len(projectDirs) > 0 {
+		firstDir = projectDirs[0]
+	}
+	if err := initRun(firstDir); err != nil {
 		log.Fatal(err)
 	}
 
+	// Several directories on the command line, or a list of projects in the
+	// config, trigger a batch run.
+	projects := projectDirs
+	if len(projects) == 0 && len(shipshape.RunConfig.Projects) > 0 {
+		projects, err = shipshape.ExpandProjects(shipshape.RunConfig.Projects)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	batch := len(projects) > 1 || (len(projectDirs) == 0 && len(projects) > 0)
+
 	if dumpConfig {
 		out, err := yaml.Marshal(shipshape.RunConfig)
 		if err != nil {
@@ -127,24 +196,54 @@ func main() {
 		os.Exit(0)
 	}
 
-	shipshape.RunChecks()
+	// Outputs provided on the command line take precedence over the config.
+	if !outputsOverridden() && len(shipshape.RunConfig.Outputs) > 0 {
+		outputs = shipshape.RunConfig.Outputs
+		shipshape.SetOutputsTemplate(outputs, templateFile)
+		if err := shipshape.ValidateOutputs(outputs); err != nil {
+			log.Fatal(err)
+		}
+	}
+	shipshape.SetOutputsLocationFile(outputs, checksFiles)
+	outputs, closeStreams, err := shipshape.StreamOutputs(os.Stdout, outputs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if shipshape.IsTerminal(os.Stdout) && showProgress(outputs) {
+		shipshape.AddEventHandler(shipshape.NewProgress(os.Stdout).HandleEvent)
+	}
 
-	switch outputFormat {
-	case "json":
-		data, err := json.Marshal(shipshape.RunResultList)
+	if batch {
+		if err := shipshape.RunProjects(projects, initRun); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		shipshape.RunChecks()
+	}
+
+	if err := closeStreams(); err != nil {
+		log.Fatal(err)
+	}
+	if err := shipshape.WriteOutputs(os.Stdout, outputs); err != nil {
+		log.Fatal(err)
+	}
+
+	if recordHistory {
+		project := shipshape.RunConfig.ProjectDir
+		if batch {
+			project = strings.Join(projects, ",")
+		}
+		rec, err := history.NewRecord(&shipshape.RunResultList,
+			project, shipshape.RunConfigHash)
 		if err != nil {
-			log.Fatalf("Unable to convert result to json: %+v\n", err)
+			log.Fatal(err)
 		}
-		fmt.Println(string(data))
-	case "junit":
-		w := bufio.NewWriter(os.Stdout)
-		shipshape.JUnit(w)
-	case "table":
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		shipshape.TableDisplay(w)
-	case "simple":
-		w := bufio.NewWriter(os.Stdout)
-		shipshape.SimpleDisplay(w)
+		path, err := history.Save(historyDir, rec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.WithField("file", path).Info("run recorded in history")
 	}
 
 	if lagoon.PushProblemsToInsightRemote {
@@ -155,9 +254,16 @@ func main() {
 		}
 	}
 
-	if shipshape.RunResultList.Status() == result.Fail && errorCodeOnFailure &&
-		len(shipshape.RunResultList.GetBreachesBySeverity(string(shipshape.RunConfig.FailSeverity))) > 0 {
+	if lagoon.PushFacts {
+		w := bufio.NewWriter(os.Stdout)
+		err := lagoon.ProcessFacts(w, shipshape.RunResultList)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 
+	if errorCodeOnFailure && shipshape.RunResultList.Policy != nil &&
+		shipshape.RunResultList.Policy.Failed {
 		os.Exit(2)
 	}
 }
@@ -167,29 +273,40 @@ func parseFlags() {
 
 	pflag.Usage = func() {
 		fmt.Fprint(os.Stderr, "Shipshape\n\nRun checks quickly on your project.\n\n")
-		fmt.Fprintf(os.Stderr, "Usage:\n  %s [dir]\n\nFlags:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage:\n  %s [dir...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s diff old.json new.json   Compare two json result files\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s merge a.json b.json...   Combine json result files into a single report\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s history                  List the recorded runs\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s trend                    Show the breaches over the recorded runs\n\nFlags:\n", os.Args[0])
 		pflag.PrintDefaults()
 	}
 
 	pflag.BoolVarP(&displayUsage, "help", "h", false, "Displays usage information")
 	pflag.BoolVarP(&displayVersion, "version", "", false, "Displays the application version")
 	pflag.BoolVar(&dumpConfig, "dump-config", false, "Dump the final config - useful to make sure multiple config files are being merged as expected")
-	pflag.BoolVar(&listChecks, "list-checks", false, "List available checks")
+	pflag.BoolVar(&listChecks, "list-checks", false, "List available checks, along with their description and fields")
 	// pflag.BoolVarP(&selfUpdate, "self-update", "u", false, "Updates shipshape to the latest version")
 
 	pflag.BoolVarP(&errorCodeOnFailure, "error-code", "e", false, "Exit with error code if a failure is detected (env: SHIPSHAPE_ERROR_ON_FAILURE)")
 	pflag.StringSliceVarP(&checksFiles, "file", "f", []string{"shipshape.yml"}, "Path to the file containing the checks. Can be specified as comma-separated single argument or using --types multiple times")
-	pflag.StringVarP(&outputFormat, "output", "o", "simple", "Output format [json|junit|simple|table] (env: SHIPSHAPE_OUTPUT_FORMAT)")
+	pflag.StringSliceVarP(&outputFormats, "output", "o", []string{"simple"}, "Output format [compliance|github|gitlab-codequality|html|json|junit|markdown|ndjson|simple|table|template]; multiple formats can be rendered in one run by providing a file for each, e.g. 'junit=junit.xml,json=result.json,simple' (env: SHIPSHAPE_OUTPUT_FORMAT)")
+	pflag.StringVar(&templateFile, "template", "", "Path to the Go template file used to render the template output format")
 	pflag.StringSliceVarP(&checkTypesToRun, "types", "t", []string(nil), "List of checks to run; default is empty, which will run all checks. Can be specified as comma-separated single argument or using --types multiple times")
 	pflag.StringVarP(&logLevel, "log-level", "l", "warn", "Level of logs to display")
 	pflag.BoolVarP(&verbose, "verbose", "v", false, "Display verbose output - equivalent to --log-level info")
 	pflag.BoolVarP(&debug, "debug", "d", false, "Display debug information - equivalent to --log-level debug")
 	pflag.BoolVarP(&excludeDb, "exclude-db", "x", false, "Exclude checks requiring a database; overrides any db checks specified by '--types'")
+	pflag.StringVar(&failSeverity, "fail-severity", "", "Minimum severity of breaches failing the run, overriding the config, or of added breaches failing 'diff' [low|normal|high|critical]")
+	pflag.StringVar(&shipshape.Environment, "environment", "", "Environment for which to apply the severity overrides (env: SHIPSHAPE_ENVIRONMENT, LAGOON_ENVIRONMENT_TYPE)")
+	pflag.BoolVar(&recordHistory, "record-history", false, "Record the results of the run in the history directory, for use with 'shipshape history' and 'shipshape trend' (env: SHIPSHAPE_RECORD_HISTORY)")
+	pflag.StringVar(&historyDir, "history-dir", history.DefaultDir, "Directory in which the runs are recorded (env: SHIPSHAPE_HISTORY_DIR)")
 	pflag.BoolVarP(&remediate, "remediate", "r", false, "Run remediation for supported checks")
 	pflag.StringVar(&lagoonApiBaseUrl, "lagoon-api-base-url", "", "Base url for the Lagoon API when pushing problems to API (env: LAGOON_API_BASE_URL)")
 	pflag.StringVar(&lagoonApiToken, "lagoon-api-token", "", "Lagoon API token when pushing problems to API (env: LAGOON_API_TOKEN)")
 	pflag.BoolVar(&lagoon.PushProblemsToInsightRemote, "lagoon-push-problems-to-insights", false, "Push audit facts to Lagoon via Insights Remote")
 	pflag.StringVar(&lagoon.LagoonInsightsRemoteEndpoint, "lagoon-insights-remote-endpoint", "http://lagoon-remote-insights-remote.lagoon.svc/problems", "Insights Remote Problems endpoint")
+	pflag.BoolVar(&lagoon.PushFacts, "lagoon-push-facts", false, "Push passes and informational data as facts to Lagoon, replacing previous ones")
+	pflag.StringVar(&lagoon.LagoonInsightsRemoteFactsEndpoint, "lagoon-insights-remote-facts-endpoint", "http://lagoon-remote-insights-remote.lagoon.svc/facts", "Insights Remote Facts endpoint")
 	pflag.Parse()
 
 	if displayUsage {
@@ -213,9 +330,28 @@ func parseEnvVars() {
 		}
 	}
 
+	recordHistoryEnv := os.Getenv("SHIPSHAPE_RECORD_HISTORY")
+	if recordHistoryEnv != "" {
+		if recordHistoryEnvBool, err := strconv.ParseBool(recordHistoryEnv); err == nil {
+			recordHistory = recordHistoryEnvBool
+		}
+	}
+
+	if historyDirEnv := os.Getenv("SHIPSHAPE_HISTORY_DIR"); historyDirEnv != "" {
+		historyDir = historyDirEnv
+	}
+
 	outputFormatEnv := os.Getenv("SHIPSHAPE_OUTPUT_FORMAT")
 	if outputFormatEnv != "" {
-		outputFormat = outputFormatEnv
+		outputFormats = strings.Split(outputFormatEnv, ",")
+	}
+
+	// The Lagoon environment type is only used as a fallback, since it is
+	// always set in Lagoon environments.
+	if environmentEnv := os.Getenv("SHIPSHAPE_ENVIRONMENT"); environmentEnv != "" {
+		shipshape.Environment = environmentEnv
+	} else if shipshape.Environment == "" {
+		shipshape.Environment = os.Getenv("LAGOON_ENVIRONMENT_TYPE")
 	}
 
 	lagoonApiBaseUrlEnv := os.Getenv("LAGOON_API_BASE_URL")
@@ -230,23 +366,117 @@ func parseEnvVars() {
 }
 
 func parseArgs() {
-	args := pflag.Args()
-	if len(args) > 1 {
-		log.Fatalf("Max 1 argument expected, got '%+v'\n", args)
-	} else if len(args) == 1 {
-		projectDir = args[0]
+	projectDirs = pflag.Args()
+}
+
+// missingChecksFile returns the first local checks file which does not exist.
+func missingChecksFile() string {
+	for _, f := range checksFiles {
+		if utils.StringIsUrl(f) {
+			continue
+		}
+		if _, err := os.Stat(f); os.IsNotExist(err) {
+			return f
+		}
+	}
+	return ""
+}
+
+// outputsOverridden determines whether the outputs were explicitly provided
+// through the flag or the environment.
+func outputsOverridden() bool {
+	return pflag.CommandLine.Changed("output") ||
+		os.Getenv("SHIPSHAPE_OUTPUT_FORMAT") != ""
+}
+
+// showProgress determines whether a live progress view can be displayed
+// before the output written to stdout.
+func showProgress(outputs []config.Output) bool {
+	for _, o := range outputs {
+		if o.File == "" && (o.Format == "simple" || o.Format == "table") {
+			return true
+		}
+	}
+	return false
+}
+
+// runDiff compares two result files and exits with an error code if
+// breaches were added at or above the fail severity.
+func runDiff() {
+	if pflag.NArg() != 3 {
+		log.Fatalf("2 result files expected for 'diff', got '%+v'", pflag.Args()[1:])
+	}
+	severity := config.HighSeverity
+	if failSeverity != "" {
+		severity = config.Severity(failSeverity)
+		if !config.IsValidSeverity(severity) {
+			log.Fatalf("invalid fail severity '%s'; needs to be one of: %s",
+				failSeverity, strings.Join(severityNames(), "|"))
+		}
+	}
+
+	old, err := diff.LoadResultList(pflag.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+	new, err := diff.LoadResultList(pflag.Arg(2))
+	if err != nil {
+		log.Fatal(err)
 	}
+	diffs := diff.Compare(old, new)
+	diff.Display(os.Stdout, diffs)
+	if len(diff.AddedAtOrAbove(diffs, severity)) > 0 {
+		os.Exit(2)
+	}
+	os.Exit(0)
 }
 
-func isValidOutputFormat(of *string) bool {
-	valid := false
-	for _, fm := range shipshape.OutputFormats {
-		if *of == fm {
-			valid = true
-			break
+// runMerge combines result files into a single report, rendered in the
+// requested outputs; each result is labelled after its file.
+func runMerge() {
+	if pflag.NArg() < 2 {
+		log.Fatal("at least 1 result file expected for 'merge'")
+	}
+	outputs, err := shipshape.ParseOutputs(outputFormats, templateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failSeverity != "" && !config.IsValidSeverity(config.Severity(failSeverity)) {
+		log.Fatalf("invalid fail severity '%s'; needs to be one of: %s",
+			failSeverity, strings.Join(severityNames(), "|"))
+	}
+
+	labels, err := diff.ResultFileLabels(pflag.Args()[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	lists := []result.ResultList{}
+	for _, f := range pflag.Args()[1:] {
+		rl, err := diff.LoadResultList(f)
+		if err != nil {
+			log.Fatal(err)
 		}
+		lists = append(lists, rl)
+	}
+
+	shipshape.RunResultList = result.Merge(labels, lists)
+	shipshape.RunConfig.FailSeverity = config.Severity(failSeverity)
+	shipshape.RunResultList.Policy = shipshape.RunConfig.EvaluateFailPolicy(&shipshape.RunResultList)
+	if err := shipshape.WriteOutputs(os.Stdout, outputs); err != nil {
+		log.Fatal(err)
+	}
+	if errorCodeOnFailure && shipshape.RunResultList.Policy.Failed {
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func severityNames() []string {
+	names := []string{}
+	for _, s := range config.Severities {
+		names = append(names, string(s))
 	}
-	return valid
+	return names
 }
 
 func determineLogLevel() {
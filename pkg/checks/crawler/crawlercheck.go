@@ -25,8 +25,11 @@ type CrawlerCheck struct {
 
 const Crawler config.CheckType = "crawler"
 
+// RegisterChecks registers the crawler check.
 func RegisterChecks() {
-	config.ChecksRegistry[Crawler] = func() config.Check { return &CrawlerCheck{} }
+	config.MustRegister(Crawler, func() config.Check { return &CrawlerCheck{} }, config.Meta{
+		Description: "Crawls a site and reports the urls returning errors",
+	})
 }
 
 func init() {
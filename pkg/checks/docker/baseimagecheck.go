@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
@@ -87,6 +88,7 @@ func (c *BaseImageCheck) RunCheck() {
 					if len(match) < 1 {
 						continue
 					}
+					c.addImageFact(name, strings.Fields(scanner.Text())[1])
 
 					if len(c.Allowed) > 0 && !utils.PackageCheckString(c.Allowed, match[1], match[2]) {
 						c.AddBreach(&result.KeyValueBreach{
@@ -109,6 +111,7 @@ func (c *BaseImageCheck) RunCheck() {
 				if len(match) < 1 {
 					continue
 				}
+				c.addImageFact(name, def.Image)
 
 				if !utils.PackageCheckString(c.Allowed, match[1], match[2]) {
 					c.AddBreach(&result.KeyValueBreach{
@@ -131,3 +134,12 @@ func (c *BaseImageCheck) RunCheck() {
 	}
 
 }
+
+// addImageFact records the base images of a service, of which there can be
+// several for multi-stage builds.
+func (c *BaseImageCheck) addImageFact(service string, image string) {
+	if images, ok := c.Result.Facts[service]; ok {
+		image = images + ", " + image
+	}
+	c.AddFact(service, image)
+}
@@ -44,6 +44,10 @@ func TestDockerfileCheck(t *testing.T) {
 		[]string{"service1 is using valid base images"},
 		c.Result.Passes,
 	)
+	assert.EqualValues(
+		map[string]string{"service1": "bitnami/kubectl:1.25.12-debian-11-r6"},
+		c.Result.Facts,
+	)
 }
 
 func TestInvalidDockerfileCheck(t *testing.T) {
@@ -124,6 +128,15 @@ func TestValidImage(t *testing.T) {
 		},
 		c.Result.Passes,
 	)
+	assert.EqualValues(
+		map[string]string{
+			"service1": "bitnami/kubectl:1.25.12-debian-11-r5",
+			"service2": "bitnami/postgresql@16",
+			"service3": "bitnami/redis:{MY_VERSION}",
+			"service4": "bitnami/mongodb:5.0.19-debian-11-r11",
+		},
+		c.Result.Facts,
+	)
 }
 
 func TestValidImageVersions(t *testing.T) {
@@ -6,8 +6,11 @@ import (
 
 //go:generate go run ../../../cmd/gen.go registry --checkpackage=docker
 
+// RegisterChecks registers the docker base image check.
 func RegisterChecks() {
-	config.ChecksRegistry[BaseImage] = func() config.Check { return &BaseImageCheck{} }
+	config.MustRegister(BaseImage, func() config.Check { return &BaseImageCheck{} }, config.Meta{
+		Description: "Checks the base images of docker-compose services and Dockerfiles",
+	})
 }
 
 func init() {
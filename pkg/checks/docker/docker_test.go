@@ -14,7 +14,9 @@ func TestRegisterChecks(t *testing.T) {
 		docker.BaseImage: "*docker.BaseImageCheck",
 	}
 	for ct, ts := range checksMap {
-		c := config.ChecksRegistry[ct]()
+		factory, ok := config.ChecksRegistry.Get(ct)
+		assert.True(t, ok)
+		c := factory()
 		ctype := reflect.TypeOf(c).String()
 		assert.Equal(t, ts, ctype)
 	}
@@ -55,21 +55,19 @@ func (c *AdminUserCheck) getActiveRoles() map[string]string {
 
 	cmd := []string{"role:list", "--fields=.", "--format=json"}
 
-	activeRoles, err := Drush(c.DrushPath, c.Alias, cmd).Exec()
+	activeRoles, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, cmd).Exec()
 	var pathErr *fs.PathError
 	if err != nil && errors.As(err, &pathErr) {
-		c.AddBreach(&result.ValueBreach{
-			Value: pathErr.Path + ": " + pathErr.Err.Error()})
+		c.AddError(pathErr.Path + ": " + pathErr.Err.Error())
 	} else if err != nil {
 		msg := string(err.(*exec.ExitError).Stderr)
-		c.AddBreach(&result.ValueBreach{
-			Value: strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", "")})
+		c.AddError(strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", ""))
 	} else {
 		// Unmarshal roles JSON.
 		err = json.Unmarshal(activeRoles, &rolesListMap)
 		var synErr *json.SyntaxError
 		if err != nil && errors.As(err, &synErr) {
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 		}
 	}
 
@@ -81,7 +79,7 @@ func (c *AdminUserCheck) FetchData() {
 	var err error
 
 	activeRoles := c.getActiveRoles()
-	if c.Result.Status == result.Fail {
+	if len(c.Result.Errors) > 0 {
 		return
 	}
 
@@ -89,14 +87,13 @@ func (c *AdminUserCheck) FetchData() {
 	rolesMap := map[string][]byte{}
 	for i := range activeRoles {
 		cmd := []string{"cget", "user.role." + i, "--format=json"}
-		rolesMap[i], err = Drush(c.DrushPath, c.Alias, cmd).Exec()
+		rolesMap[i], err = Drush(c.GetProjectDir(), c.DrushPath, c.Alias, cmd).Exec()
 		c.DataMap = rolesMap
 	}
 
 	if err != nil {
 		msg := string(err.(*exec.ExitError).Stderr)
-		c.AddBreach(&result.ValueBreach{
-			Value: strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", "")})
+		c.AddError(strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", ""))
 	}
 }
 
@@ -114,7 +111,7 @@ func (c *AdminUserCheck) UnmarshalDataMap() {
 		err := json.Unmarshal([]byte(element), &role)
 		var synErr *json.SyntaxError
 		if err != nil && errors.As(err, &synErr) {
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 			return
 		}
 		// Collect role config.
@@ -140,6 +137,9 @@ func (c *AdminUserCheck) RunCheck() {
 	}
 }
 
+// SupportsRemediation implements config.RemediationSupporter.
+func (c *AdminUserCheck) SupportsRemediation() bool { return true }
+
 // Remediate attempts to fix a breach.
 func (c *AdminUserCheck) Remediate() {
 	for _, b := range c.Result.Breaches {
@@ -148,7 +148,7 @@ func (c *AdminUserCheck) Remediate() {
 			continue
 		}
 
-		_, err := Drush(c.DrushPath, c.Alias, []string{"config:set", "user.role." + b.Value, "is_admin", "0"}).Exec()
+		_, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, []string{"config:set", "user.role." + b.Value, "is_admin", "0"}).Exec()
 		if err != nil {
 			b.SetRemediation(result.RemediationStatusFailed, fmt.Sprintf(
 				"failed to set is_admin to false for role '%s' due to error: %s",
@@ -50,13 +50,8 @@ func TestAdminUserFetchData(t *testing.T) {
 	t.Run("drushNotFound", func(t *testing.T) {
 		c := AdminUserCheck{}
 		c.FetchData()
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "vendor/drush/drush/drush: no such file or directory",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"vendor/drush/drush/drush: no such file or directory"}, c.Result.Errors)
 	})
 
 	curShellCommander := command.ShellCommander
@@ -69,13 +64,8 @@ func TestAdminUserFetchData(t *testing.T) {
 		)
 		c := AdminUserCheck{}
 		c.FetchData()
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "unable to run drush command",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"unable to run drush command"}, c.Result.Errors)
 	})
 
 	// correct data.
@@ -119,13 +109,8 @@ func TestAdminUserUnmarshalData(t *testing.T) {
 			},
 		}
 		c.UnmarshalDataMap()
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "invalid character ']' after object key:value pair",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"invalid character ']' after object key:value pair"}, c.Result.Errors)
 	})
 
 	// Correct json.
@@ -79,6 +79,9 @@ func (c *DbPermissionsCheck) RunCheck() {
 	}
 }
 
+// SupportsRemediation implements config.RemediationSupporter.
+func (c *DbPermissionsCheck) SupportsRemediation() bool { return true }
+
 // Remediate attempts to remove any disallowed permissions detected.
 func (c *DbPermissionsCheck) Remediate() {
 	for _, b := range c.Result.Breaches {
@@ -87,7 +90,7 @@ func (c *DbPermissionsCheck) Remediate() {
 			continue
 		}
 		_, err := Drush(
-			c.DrushPath, c.Alias,
+			c.GetProjectDir(), c.DrushPath, c.Alias,
 			[]string{"role:perm:remove", b.Key, strings.Join(b.Values, ",")}).Exec()
 		if err != nil {
 			b.SetRemediation(result.RemediationStatusFailed, fmt.Sprintf(
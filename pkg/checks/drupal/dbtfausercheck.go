@@ -45,13 +45,10 @@ func (c *DbUserTfaCheck) FetchData() {
 						WHERE users.uid = users_data.uid
 						 	AND users_data.module = 'tfa');\")->fetchAll()`,
 		"--format=json"}
-	res, err := Drush(c.DrushPath, c.Alias, cmd).Exec()
+	res, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, cmd).Exec()
 	if err != nil {
 		c.Result.Status = result.Fail
-		c.AddBreach(&result.ValueBreach{
-			ValueLabel: "error fetching drush user info",
-			Value:      command.GetMsgFromCommandError(err),
-		})
+		c.AddError("error fetching drush user info: " + command.GetMsgFromCommandError(err))
 	}
 	c.DataMap = map[string][]byte{}
 	c.DataMap["db-tfa-check"] = res
@@ -83,5 +80,5 @@ func (c *DbUserTfaCheck) RunCheck() {
 
 // Merge implmentation for DbUserTfaCheck check.
 func (c *DbUserTfaCheck) Merge(mergeCheck config.Check) error {
-	return nil
+	return c.CheckBase.Merge(mergeCheck)
 }
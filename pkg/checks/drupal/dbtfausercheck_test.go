@@ -28,16 +28,8 @@ func TestDbTfaUserCheck(t *testing.T) {
 		c.FetchData()
 		assert.Equal(result.Fail, c.Result.Status)
 		assert.Empty(c.Result.Passes)
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "drupal-db-user-tfa",
-				Severity:   "normal",
-				ValueLabel: "error fetching drush user info",
-				Value:      "unable to run drush command",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"error fetching drush user info: unable to run drush command"}, c.Result.Errors)
 	})
 
 	t.Run("failOnSingleUserWithoutTFA", func(t *testing.T) {
@@ -2,6 +2,7 @@ package drupal
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	yamlv3 "gopkg.in/yaml.v3"
@@ -13,17 +14,39 @@ import (
 
 //go:generate go run ../../../cmd/gen.go registry --checkpackage=drupal
 
+// RegisterChecks registers the Drupal checks, most of which inspect the
+// site through drush.
 func RegisterChecks() {
-	config.ChecksRegistry[DrushYaml] = func() config.Check { return &DrushYamlCheck{} }
-	config.ChecksRegistry[FileModule] = func() config.Check { return &FileModuleCheck{} }
-	config.ChecksRegistry[DbModule] = func() config.Check { return &DbModuleCheck{} }
-	config.ChecksRegistry[DbPermissions] = func() config.Check { return &DbPermissionsCheck{} }
-	config.ChecksRegistry[RolePermissions] = func() config.Check { return &RolePermissionsCheck{} }
-	config.ChecksRegistry[TrackingCode] = func() config.Check { return &TrackingCodeCheck{} }
-	config.ChecksRegistry[UserRole] = func() config.Check { return &UserRoleCheck{} }
-	config.ChecksRegistry[AdminUser] = func() config.Check { return &AdminUserCheck{} }
-	config.ChecksRegistry[DbUserTfa] = func() config.Check { return &DbUserTfaCheck{} }
-	config.ChecksRegistry[ForbiddenUser] = func() config.Check { return &ForbiddenUserCheck{} }
+	config.MustRegister(DrushYaml, func() config.Check { return &DrushYamlCheck{} }, config.Meta{
+		Description: "Checks the yaml output of a drush command for required/disallowed values",
+	})
+	config.MustRegister(FileModule, func() config.Check { return &FileModuleCheck{} }, config.Meta{
+		Description: "Checks the required/disallowed Drupal modules in the exported config",
+	})
+	config.MustRegister(DbModule, func() config.Check { return &DbModuleCheck{} }, config.Meta{
+		Description: "Checks the required/disallowed Drupal modules in the database",
+	})
+	config.MustRegister(DbPermissions, func() config.Check { return &DbPermissionsCheck{} }, config.Meta{
+		Description: "Checks that no role has disallowed Drupal permissions",
+	})
+	config.MustRegister(RolePermissions, func() config.Check { return &RolePermissionsCheck{} }, config.Meta{
+		Description: "Checks the required/disallowed Drupal permissions of a role",
+	})
+	config.MustRegister(TrackingCode, func() config.Check { return &TrackingCodeCheck{} }, config.Meta{
+		Description: "Checks that a tracking code is present on the site",
+	})
+	config.MustRegister(UserRole, func() config.Check { return &UserRoleCheck{} }, config.Meta{
+		Description: "Checks that only allowed users have the specified Drupal roles",
+	})
+	config.MustRegister(AdminUser, func() config.Check { return &AdminUserCheck{} }, config.Meta{
+		Description: "Checks that only allowed Drupal roles are admin roles",
+	})
+	config.MustRegister(DbUserTfa, func() config.Check { return &DbUserTfaCheck{} }, config.Meta{
+		Description: "Checks that Drupal users have two-factor authentication set up",
+	})
+	config.MustRegister(ForbiddenUser, func() config.Check { return &ForbiddenUserCheck{} }, config.Meta{
+		Description: "Checks if a forbidden Drupal user is active",
+	})
 }
 
 func init() {
@@ -72,10 +95,8 @@ func CheckModulesInYaml(c *yaml.YamlBase, ct config.CheckType, configName string
 	required_enabled,
 		required_errored,
 		required_disabled := DetermineModuleStatus(c.NodeMap[configName], ct, required)
-	if len(required_errored) > 0 {
-		c.AddBreach(&result.KeyValuesBreach{
-			Key:    "error verifying status for required modules",
-			Values: required_errored})
+	for _, e := range required_errored {
+		c.AddError("error verifying status for required modules: " + e)
 	}
 	if len(required_disabled) > 0 {
 		c.AddBreach(&result.KeyValuesBreach{
@@ -93,10 +114,8 @@ func CheckModulesInYaml(c *yaml.YamlBase, ct config.CheckType, configName string
 	disallowed_enabled,
 		disallowed_errored,
 		disallowed_disabled := DetermineModuleStatus(c.NodeMap[configName], ct, disallowed)
-	if len(disallowed_errored) > 0 {
-		c.AddBreach(&result.KeyValuesBreach{
-			Key:    "error verifying status for disallowed modules",
-			Values: disallowed_errored})
+	for _, e := range disallowed_errored {
+		c.AddError("error verifying status for disallowed modules: " + e)
 	}
 	if len(disallowed_enabled) > 0 {
 		c.AddBreach(&result.KeyValuesBreach{
@@ -111,6 +130,15 @@ func CheckModulesInYaml(c *yaml.YamlBase, ct config.CheckType, configName string
 			strings.Join(disallowed_disabled, ",")))
 	}
 
+	// Only the modules listed in the check are known, hence "checked".
+	enabled := make([]string, 0, len(required_enabled)+len(disallowed_enabled))
+	enabled = append(enabled, required_enabled...)
+	enabled = append(enabled, disallowed_enabled...)
+	if len(enabled) > 0 {
+		sort.Strings(enabled)
+		c.AddFact("checked enabled modules", strings.Join(enabled, ","))
+	}
+
 	if len(c.Result.Breaches) == 0 {
 		c.Result.Status = result.Pass
 	}
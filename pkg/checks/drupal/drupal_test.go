@@ -25,10 +25,28 @@ func TestRegisterChecks(t *testing.T) {
 		DbUserTfa:     "*drupal.DbUserTfaCheck",
 	}
 	for ct, ts := range checksMap {
-		c := config.ChecksRegistry[ct]()
+		factory, ok := config.ChecksRegistry.Get(ct)
+		assert.True(t, ok)
+		c := factory()
 		ctype := reflect.TypeOf(c).String()
 		assert.Equal(t, ts, ctype)
 	}
+
+	remediation := map[config.CheckType]bool{
+		DrushYaml:     true,
+		FileModule:    false,
+		DbModule:      true,
+		DbPermissions: true,
+		TrackingCode:  true,
+		UserRole:      false,
+		AdminUser:     true,
+		ForbiddenUser: true,
+	}
+	for ct, supported := range remediation {
+		meta, _ := config.ChecksRegistry.Meta(ct)
+		assert.Equal(t, supported, meta.SupportsRemediation, ct)
+		assert.Equal(t, ct != FileModule, meta.RequiresDb, ct)
+	}
 }
 
 func TestModuleYamlKey(t *testing.T) {
@@ -269,19 +287,11 @@ func TestCheckModulesInYaml(t *testing.T) {
 		"some required modules are enabled: block",
 		"some disallowed modules are disabled: views_ui",
 	})
-	assert.ElementsMatch(
-		[]result.Breach{
-			&result.KeyValuesBreach{
-				BreachType: "key-values",
-				Key:        "error verifying status for required modules",
-				Values:     []string{"invalid character '&' at position 11, following \".node\""},
-			},
-			&result.KeyValuesBreach{
-				BreachType: "key-values",
-				Key:        "error verifying status for disallowed modules",
-				Values:     []string{"invalid character '&' at position 15, following \".field_ui\""},
-			},
-		}, c.Result.Breaches)
+	assert.Empty(c.Result.Breaches)
+	assert.ElementsMatch([]string{
+		"error verifying status for required modules: invalid character '&' at position 11, following \".node\"",
+		"error verifying status for disallowed modules: invalid character '&' at position 15, following \".field_ui\"",
+	}, c.Result.Errors)
 
 	// Required is not enabled & disallowed is enabled.
 	c = mockCheck("shipshape.extension.yml")
@@ -307,6 +317,7 @@ module:
 		"some required modules are enabled: block",
 		"some disallowed modules are disabled: field_ui",
 	})
+	assert.EqualValues(map[string]string{"checked enabled modules": "block,views_ui"}, c.Result.Facts)
 	assert.ElementsMatch(
 		[]result.Breach{
 			&result.KeyValuesBreach{
@@ -4,20 +4,20 @@ import (
 	"path/filepath"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
-	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/utils"
 )
 
 const DrushDefaultPath = "vendor/drush/drush/drush"
 
 // Drush is a simple wrapper around DrushCommand which allows chaining
-// commands for Drush, e.g, `Drush("", "", "status").Exec()`.
-func Drush(drushPath string, alias string, command []string) *DrushCommand {
+// commands for Drush, e.g, `Drush("", "", "", "status").Exec()`. A relative
+// drushPath is resolved against projectDir.
+func Drush(projectDir string, drushPath string, alias string, command []string) *DrushCommand {
 	if drushPath == "" {
 		drushPath = DrushDefaultPath
 	}
 	if !filepath.IsAbs(drushPath) {
-		drushPath = filepath.Join(config.ProjectDir, drushPath)
+		drushPath = filepath.Join(projectDir, drushPath)
 	}
 	return &DrushCommand{DrushPath: drushPath, Alias: alias, Args: command}
 }
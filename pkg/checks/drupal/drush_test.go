@@ -39,13 +39,13 @@ func TestDrushExec(t *testing.T) {
 	t.Run("commandNotFound", func(t *testing.T) {
 		command.ShellCommander = internal.ShellCommanderMaker(
 			nil, errors.New("bash: drushfoo: command not found"), nil)
-		_, err := drupal.Drush("", "", []string{"status"}).Exec()
+		_, err := drupal.Drush("", "", "", []string{"status"}).Exec()
 		assert.Error(err, "bash: drushfoo: command not found")
 	})
 
 	t.Run("ok", func(t *testing.T) {
 		command.ShellCommander = internal.ShellCommanderMaker(&[]string{"foobar"}[0], nil, nil)
-		out, err := drupal.Drush("", "local", []string{"status"}).Exec()
+		out, err := drupal.Drush("", "", "local", []string{"status"}).Exec()
 		assert.NoError(err)
 		assert.Equal([]byte("foobar"), out)
 	})
@@ -59,7 +59,7 @@ func TestDrushQuery(t *testing.T) {
 	var generatedCommand string
 	command.ShellCommander = internal.ShellCommanderMaker(nil, nil, &generatedCommand)
 
-	_, err := drupal.Drush("", "", []string{}).Query("SELECT uid FROM users")
+	_, err := drupal.Drush("", "", "", []string{}).Query("SELECT uid FROM users")
 	assert.NoError(t, err)
 	assert.Equal(t, "vendor/drush/drush/drush sql:query 'SELECT uid FROM users'", generatedCommand)
 }
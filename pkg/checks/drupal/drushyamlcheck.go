@@ -40,20 +40,20 @@ func (c *DrushYamlCheck) FetchData() {
 	var err error
 	c.DataMap = map[string][]byte{}
 	c.DrushCommand.Args = append(strings.Fields(c.Command), "--format=yaml")
-	c.DataMap[c.ConfigName], err = Drush(c.DrushPath, c.Alias, c.DrushCommand.Args).Exec()
+	c.DataMap[c.ConfigName], err = Drush(c.GetProjectDir(), c.DrushPath, c.Alias, c.DrushCommand.Args).Exec()
 	if err != nil {
 		if pathErr, ok := err.(*fs.PathError); ok {
-			c.AddBreach(&result.ValueBreach{
-				Value: pathErr.Path + ": " + pathErr.Err.Error()})
+			c.AddError(pathErr.Path + ": " + pathErr.Err.Error())
 		} else {
 			msg := string(err.(*exec.ExitError).Stderr)
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: c.ConfigName,
-				Value:      strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", "")})
+			c.AddError(c.ConfigName + ": " + strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", ""))
 		}
 	}
 }
 
+// SupportsRemediation implements config.RemediationSupporter.
+func (c *DrushYamlCheck) SupportsRemediation() bool { return true }
+
 // Remediate attempts to remediate a breach by running the drush command
 // specified in the check.
 func (c *DrushYamlCheck) Remediate() {
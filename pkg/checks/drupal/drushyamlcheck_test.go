@@ -76,12 +76,7 @@ func TestDrushYamlCheckFetchData(t *testing.T) {
 				Command:    "status",
 				ConfigName: "core.extension",
 			},
-			ExpectBreaches: []result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "drush-yaml",
-				Severity:   "normal",
-				Value:      "vendor/drush/drush/drush: no such file or directory",
-			}},
+			ExpectErrors: []string{"vendor/drush/drush/drush: no such file or directory"},
 		},
 
 		{
@@ -97,13 +92,7 @@ func TestDrushYamlCheckFetchData(t *testing.T) {
 					nil,
 				)
 			},
-			ExpectBreaches: []result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "drush-yaml",
-				Severity:   "normal",
-				ValueLabel: "core.extension",
-				Value:      "unable to run drush command",
-			}},
+			ExpectErrors: []string{"core.extension: unable to run drush command"},
 		},
 
 		{
@@ -37,15 +37,13 @@ func (c *ForbiddenUserCheck) CheckUserStatus() bool {
 	// Command: drush user:info --uid=1 --fields=user_status --format=json
 	cmd := []string{"user:info", "--uid=" + c.UserId, "--fields=user_status", "--format=json"}
 
-	userStatus, err := Drush(c.DrushPath, c.Alias, cmd).Exec()
+	userStatus, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, cmd).Exec()
 	var pathError *fs.PathError
 	if err != nil && errors.As(err, &pathError) {
-		c.AddBreach(&result.ValueBreach{
-			Value: pathError.Path + ": " + pathError.Err.Error()})
+		c.AddError(pathError.Path + ": " + pathError.Err.Error())
 	} else if err != nil {
 		msg := string(err.(*exec.ExitError).Stderr)
-		c.AddBreach(&result.ValueBreach{
-			Value: strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", "")})
+		c.AddError(strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", ""))
 	} else {
 		// Unmarshal user:info JSON.
 		// {
@@ -57,7 +55,7 @@ func (c *ForbiddenUserCheck) CheckUserStatus() bool {
 		err = json.Unmarshal(userStatus, &userStatusMap)
 		var syntaxError *json.SyntaxError
 		if err != nil && errors.As(err, &syntaxError) {
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 		}
 
 		if userStatusMap[c.UserId]["user_status"] == "1" {
@@ -68,6 +66,9 @@ func (c *ForbiddenUserCheck) CheckUserStatus() bool {
 	return false
 }
 
+// SupportsRemediation implements config.RemediationSupporter.
+func (c *ForbiddenUserCheck) SupportsRemediation() bool { return true }
+
 // Remediate attempts to block an active forbidden user.
 func (c *ForbiddenUserCheck) Remediate() {
 	for _, b := range c.Result.Breaches {
@@ -75,7 +76,7 @@ func (c *ForbiddenUserCheck) Remediate() {
 			continue
 		}
 
-		_, err := Drush(c.DrushPath, c.Alias, []string{"user:block", "--uid=" + c.UserId}).Exec()
+		_, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, []string{"user:block", "--uid=" + c.UserId}).Exec()
 		if err != nil {
 			b.SetRemediation(result.RemediationStatusFailed, fmt.Sprintf(
 				"error blocking forbidden user '%s' due to error: %s",
@@ -90,7 +91,7 @@ func (c *ForbiddenUserCheck) Remediate() {
 
 // Merge implementation for ForbiddenUserCheck check.
 func (c *ForbiddenUserCheck) Merge(mergeCheck config.Check) error {
-	return nil
+	return c.CheckBase.Merge(mergeCheck)
 }
 
 // HasData implementation for ForbiddenUserCheck check.
@@ -108,7 +109,7 @@ func (c *ForbiddenUserCheck) RunCheck() {
 		})
 	}
 
-	if len(c.Result.Breaches) == 0 {
+	if len(c.Result.Breaches) == 0 && len(c.Result.Errors) == 0 {
 		c.Result.Status = result.Pass
 		c.AddPass("No forbidden user is active.")
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/salsadigitalauorg/shipshape/pkg/checks/drupal"
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/internal"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,18 @@ func TestForbiddenUserCheck_Merge(t *testing.T) {
 	c := drupal.ForbiddenUserCheck{}
 	c.Init(drupal.ForbiddenUser)
 	assert.Nil(t, c.Merge(&c))
+
+	// The metadata is merged although the check relies on CheckBase.Merge.
+	c = drupal.ForbiddenUserCheck{CheckBase: config.CheckBase{Name: "admin"}}
+	assert.Nil(t, c.Merge(&drupal.ForbiddenUserCheck{CheckBase: config.CheckBase{
+		Name:        "admin",
+		Description: "The admin user is blocked",
+		Rationale:   "The admin user is targeted by attacks",
+		Controls:    []string{"ISM-1175"},
+	}}))
+	assert.Equal(t, "The admin user is blocked", c.Description)
+	assert.Equal(t, "The admin user is targeted by attacks", c.Rationale)
+	assert.Equal(t, []string{"ISM-1175"}, c.Controls)
 }
 
 func TestForbiddenUserCheck_HasData(t *testing.T) {
@@ -47,12 +60,8 @@ func TestForbiddenUserCheck_RunCheck(t *testing.T) {
 		c.RunCheck()
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
-		assertions.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "vendor/drush/drush/drush: no such file or directory",
-			}},
-			c.Result.Breaches)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"vendor/drush/drush/drush: no such file or directory"}, c.Result.Errors)
 	})
 
 	t.Run("failOnDrushError", func(t *testing.T) {
@@ -67,15 +76,8 @@ func TestForbiddenUserCheck_RunCheck(t *testing.T) {
 		)
 		c.RunCheck()
 		assertions.Empty(c.Result.Passes)
-		assertions.ElementsMatch(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "drupal-user-forbidden",
-				Severity:   "normal",
-				Value:      "Unable to find a matching user",
-			}},
-			c.Result.Breaches,
-		)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"Unable to find a matching user"}, c.Result.Errors)
 	})
 
 	t.Run("failOnDrushInvalidResponse", func(t *testing.T) {
@@ -93,15 +95,8 @@ func TestForbiddenUserCheck_RunCheck(t *testing.T) {
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
 		assertions.Empty(c.Result.Passes)
-		assertions.ElementsMatch(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "drupal-user-forbidden",
-				Severity:   "normal",
-				Value:      "invalid character 'U' looking for beginning of value",
-			}},
-			c.Result.Breaches,
-		)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"invalid character 'U' looking for beginning of value"}, c.Result.Errors)
 	})
 
 	t.Run("failOnActiveUser", func(t *testing.T) {
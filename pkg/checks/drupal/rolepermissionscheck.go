@@ -33,7 +33,7 @@ func (c *RolePermissionsCheck) Init(ct config.CheckType) {
 
 // Merge implementation for RolePermissionsCheck check.
 func (c *RolePermissionsCheck) Merge(mergeCheck config.Check) error {
-	return nil
+	return c.CheckBase.Merge(mergeCheck)
 }
 
 // HasData implementation for RolePermissionsCheck check.
@@ -46,10 +46,10 @@ func (c *RolePermissionsCheck) GetRolePermissions() []string {
 	// Command: drush role:list --filter=id=anonymous --fields=perms --format=json
 	cmd := []string{"role:list", "--filter=id=" + c.RoleId, "--fields=perms", "--format=json"}
 
-	drushOutput, err := Drush(c.DrushPath, c.Alias, cmd).Exec()
+	drushOutput, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, cmd).Exec()
 
 	if err != nil {
-		c.AddBreach(&result.ValueBreach{Value: command.GetMsgFromCommandError(err)})
+		c.AddError(command.GetMsgFromCommandError(err))
 	} else {
 		// Unmarshal role:list JSON.
 		// {
@@ -66,7 +66,7 @@ func (c *RolePermissionsCheck) GetRolePermissions() []string {
 		err = json.Unmarshal(drushOutput, &rolePermissionsMap)
 		var syntaxError *json.SyntaxError
 		if err != nil && errors.As(err, &syntaxError) {
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 		}
 
 		if len(rolePermissionsMap[c.RoleId]["perms"]) > 0 {
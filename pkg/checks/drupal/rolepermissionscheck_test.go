@@ -59,11 +59,8 @@ func TestRolePermissionsCheck_RunCheck(t *testing.T) {
 		c.RunCheck()
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
-		assertions.ElementsMatch(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: result.BreachTypeValue,
-				Value:      "vendor/drush/drush/drush: no such file or directory"}},
-			c.Result.Breaches)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"vendor/drush/drush/drush: no such file or directory"}, c.Result.Errors)
 	})
 
 	t.Run("failOnDrushError", func(t *testing.T) {
@@ -81,13 +78,8 @@ func TestRolePermissionsCheck_RunCheck(t *testing.T) {
 		c.RunCheck()
 		c.Result.DetermineResultStatus(false)
 		assertions.Empty(c.Result.Passes)
-		assertions.ElementsMatch(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: result.BreachTypeValue,
-				CheckType:  "drupal-role-permissions",
-				Severity:   "normal",
-				Value:      "Unexpected error"}},
-			c.Result.Breaches)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"Unexpected error"}, c.Result.Errors)
 	})
 
 	t.Run("failOnDrushInvalidResponse", func(t *testing.T) {
@@ -107,13 +99,8 @@ func TestRolePermissionsCheck_RunCheck(t *testing.T) {
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
 		assertions.Empty(c.Result.Passes)
-		assertions.ElementsMatch(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: result.BreachTypeValue,
-				CheckType:  "drupal-role-permissions",
-				Severity:   "normal",
-				Value:      "invalid character 'U' looking for beginning of value"}},
-			c.Result.Breaches)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"invalid character 'U' looking for beginning of value"}, c.Result.Errors)
 	})
 
 	t.Run("failOnPermissions", func(t *testing.T) {
@@ -42,7 +42,7 @@ func (c *TrackingCodeCheck) UnmarshalDataMap() {
 	err := yaml.Unmarshal(c.DataMap[c.ConfigName], &c.DrushStatus)
 	if err != nil {
 		if _, ok := err.(*yaml.TypeError); !ok {
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 			return
 		}
 	}
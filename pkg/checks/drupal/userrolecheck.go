@@ -52,16 +52,14 @@ func (c *UserRoleCheck) Merge(mergeCheck config.Check) error {
 }
 
 func (c *UserRoleCheck) getUserIds() string {
-	userIds, err := Drush(c.DrushPath, c.Alias, c.Args).Query("SELECT GROUP_CONCAT(uid) FROM users")
+	userIds, err := Drush(c.GetProjectDir(), c.DrushPath, c.Alias, c.Args).Query("SELECT GROUP_CONCAT(uid) FROM users")
 
 	var pathErr *fs.PathError
 	if err != nil && errors.As(err, &pathErr) {
-		c.AddBreach(&result.ValueBreach{
-			Value: pathErr.Path + ": " + pathErr.Err.Error()})
+		c.AddError(pathErr.Path + ": " + pathErr.Err.Error())
 	} else if err != nil {
 		msg := string(err.(*exec.ExitError).Stderr)
-		c.AddBreach(&result.ValueBreach{
-			Value: strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", "")})
+		c.AddError(strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", ""))
 	}
 	return string(userIds)
 }
@@ -71,17 +69,16 @@ func (c *UserRoleCheck) FetchData() {
 	var err error
 
 	userIds := c.getUserIds()
-	if len(c.Result.Breaches) > 0 {
+	if len(c.Result.Errors) > 0 {
 		return
 	}
 
 	c.DataMap = map[string][]byte{}
 	cmd := []string{"user:information", "--uid=" + userIds, "--fields=roles", "--format=json"}
-	c.DataMap["user-info"], err = Drush(c.DrushPath, c.Alias, cmd).Exec()
+	c.DataMap["user-info"], err = Drush(c.GetProjectDir(), c.DrushPath, c.Alias, cmd).Exec()
 	if err != nil {
 		msg := command.GetMsgFromCommandError(err)
-		c.AddBreach(&result.ValueBreach{
-			Value: strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", "")})
+		c.AddError(strings.ReplaceAll(strings.TrimSpace(msg), "  \n  ", ""))
 	}
 }
 
@@ -97,7 +94,7 @@ func (c *UserRoleCheck) UnmarshalDataMap() {
 	err := json.Unmarshal(c.DataMap["user-info"], &userInfoMap)
 	var synErr *json.SyntaxError
 	if err != nil && errors.As(err, &synErr) {
-		c.AddBreach(&result.ValueBreach{Value: err.Error()})
+		c.AddError(err.Error())
 		return
 	}
 
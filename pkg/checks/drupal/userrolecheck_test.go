@@ -53,13 +53,8 @@ func TestUserRoleCheckFetchData(t *testing.T) {
 	t.Run("drushNotFound", func(t *testing.T) {
 		c := UserRoleCheck{}
 		c.FetchData()
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "vendor/drush/drush/drush: no such file or directory",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"vendor/drush/drush/drush: no such file or directory"}, c.Result.Errors)
 	})
 
 	t.Run("drushError", func(t *testing.T) {
@@ -82,24 +77,14 @@ func TestUserRoleCheckFetchData(t *testing.T) {
 		}
 		c := UserRoleCheck{}
 		c.FetchData()
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "unable to run drush sql query",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"unable to run drush sql query"}, c.Result.Errors)
 
 		sqlQueryFail = false
 		c = UserRoleCheck{}
 		c.FetchData()
-		assert.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				BreachType: "value",
-				Value:      "unable to run drush command",
-			}},
-			c.Result.Breaches,
-		)
+		assert.Empty(c.Result.Breaches)
+		assert.Equal([]string{"unable to run drush command"}, c.Result.Errors)
 	})
 
 	// correct data.
@@ -139,13 +124,8 @@ func TestUserRoleCheckUnmarshalData(t *testing.T) {
 		},
 	}
 	c.UnmarshalDataMap()
-	assert.EqualValues(
-		[]result.Breach{&result.ValueBreach{
-			BreachType: "value",
-			Value:      "invalid character ']' after object key:value pair",
-		}},
-		c.Result.Breaches,
-	)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"invalid character ']' after object key:value pair"}, c.Result.Errors)
 
 	// Correct json.
 	c = UserRoleCheck{
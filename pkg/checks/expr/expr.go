@@ -0,0 +1,391 @@
+// Package expr provides a check evaluating an expression over data loaded
+// from files, command outputs or the results of other checks.
+//
+// Expressions use the Jinja syntax, as implemented by gonja, e.g.
+// `not ('redis' in extension.module) or settings.host`.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/nikolalohinski/gonja/v2"
+	"github.com/nikolalohinski/gonja/v2/exec"
+	"github.com/nikolalohinski/gonja/v2/nodes"
+	"github.com/nikolalohinski/gonja/v2/parser"
+	"github.com/nikolalohinski/gonja/v2/tokens"
+	shipjson "github.com/salsadigitalauorg/shipshape/pkg/checks/json"
+	"github.com/salsadigitalauorg/shipshape/pkg/command"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:generate go run ../../../cmd/gen.go registry --checkpackage=expr
+
+const Expr config.CheckType = "expr"
+
+// Formats in which the data can be parsed.
+const (
+	FormatJson = "json"
+	FormatYaml = "yaml"
+	FormatText = "text"
+)
+
+// DataSource defines where the value of a variable of the expression comes
+// from; exactly one of File, Command or Check is expected.
+type DataSource struct {
+	// A file, relative to the project directory.
+	File string `yaml:"file"`
+	// A command line, run with sh -c in the project directory.
+	Command string `yaml:"command"`
+	// The name of another check, whose result is provided.
+	Check string `yaml:"check"`
+	// The format of the file or command output: json, yaml or text. Defaults
+	// to the file's extension, or text.
+	Format string `yaml:"format"`
+	// A path to look up in the parsed data: a yaml path for yaml, or a
+	// JSONPath or JMESPath for json.
+	Key string `yaml:"key"`
+	// If set, the variable is none when the data is missing instead of the
+	// check failing.
+	Optional bool `yaml:"optional"`
+}
+
+// ExprCheck evaluates a boolean expression, failing with the message when
+// it is false.
+type ExprCheck struct {
+	config.CheckBase `yaml:",inline"`
+	// The data available to the expression, keyed by variable name.
+	Data       map[string]DataSource `yaml:"data"`
+	Expression string                `yaml:"expression"`
+	// A template of the breach message, which has access to the same
+	// variables as the expression.
+	Message string `yaml:"message"`
+
+	vars    map[string]any
+	results []result.Result
+}
+
+// RegisterChecks registers the expression check.
+func RegisterChecks() {
+	config.MustRegister(Expr, func() config.Check { return &ExprCheck{} }, config.Meta{
+		Description: "Evaluates an expression over data from files, commands or other checks",
+	})
+}
+
+func init() {
+	RegisterChecks()
+}
+
+// Merge implementation for the expr check.
+func (c *ExprCheck) Merge(mergeCheck config.Check) error {
+	exprMergeCheck := mergeCheck.(*ExprCheck)
+	if err := c.CheckBase.Merge(&exprMergeCheck.CheckBase); err != nil {
+		return err
+	}
+
+	for k, v := range exprMergeCheck.Data {
+		if c.Data == nil {
+			c.Data = map[string]DataSource{}
+		}
+		c.Data[k] = v
+	}
+	utils.MergeString(&c.Expression, exprMergeCheck.Expression)
+	utils.MergeString(&c.Message, exprMergeCheck.Message)
+	return nil
+}
+
+// ResultsDependencies implements config.ResultsConsumer, so that the check
+// runs after the checks it refers to.
+func (c *ExprCheck) ResultsDependencies() []string {
+	deps := []string{}
+	for _, ds := range c.Data {
+		if ds.Check != "" {
+			deps = append(deps, ds.Check)
+		}
+	}
+	return deps
+}
+
+// SetResults implements config.ResultsConsumer.
+func (c *ExprCheck) SetResults(results []result.Result) {
+	c.results = results
+}
+
+// FetchData loads the data of the variables.
+func (c *ExprCheck) FetchData() {
+	if c.Expression == "" {
+		c.AddError("expression required")
+		return
+	}
+
+	c.vars = map[string]any{}
+	for name, ds := range c.Data {
+		v, err := c.load(ds)
+		if err != nil {
+			c.AddError(fmt.Sprintf("data '%s': %s", name, err))
+			continue
+		}
+		c.vars[name] = v
+	}
+	// The expression is only evaluated when all its data is available.
+	if len(c.Result.Errors) == 0 {
+		c.DataMap = map[string][]byte{}
+	}
+}
+
+// HasData is overridden so that data which failed to load is only reported
+// once.
+func (c *ExprCheck) HasData(failCheck bool) bool {
+	if c.DataMap == nil && len(c.Result.Errors) > 0 {
+		return false
+	}
+	return c.CheckBase.HasData(failCheck)
+}
+
+// RunCheck evaluates the expression.
+func (c *ExprCheck) RunCheck() {
+	ok, err := Evaluate(c.Expression, c.vars)
+	if err != nil {
+		c.AddError(fmt.Sprintf("expression '%s': %s", c.Expression, err))
+		return
+	}
+	if ok {
+		c.AddPass(fmt.Sprintf("expression '%s' is true", c.Expression))
+		c.Result.Status = result.Pass
+		return
+	}
+
+	msg := fmt.Sprintf("expression '%s' is false", c.Expression)
+	if c.Message != "" {
+		if msg, err = Render(c.Message, c.vars); err != nil {
+			msg = fmt.Sprintf("unable to render message: %s", err)
+		}
+	}
+	c.AddBreach(&result.ValueBreach{Value: msg})
+}
+
+func (c *ExprCheck) load(ds DataSource) (any, error) {
+	var data []byte
+	var err error
+	format := ds.Format
+	switch {
+	case ds.Check != "":
+		return c.checkResult(ds)
+	case ds.File != "":
+		fpath := ds.File
+		if !filepath.IsAbs(fpath) {
+			fpath = filepath.Join(c.GetProjectDir(), fpath)
+		}
+		data, err = os.ReadFile(fpath)
+		if os.IsNotExist(err) && ds.Optional {
+			return nil, nil
+		}
+		if format == "" {
+			switch filepath.Ext(fpath) {
+			case ".json":
+				format = FormatJson
+			case ".yml", ".yaml":
+				format = FormatYaml
+			}
+		}
+	case ds.Command != "":
+		var res command.Result
+		res, err = command.Run(command.ShellCommander("sh", "-c", ds.Command),
+			command.Options{Dir: c.GetProjectDir()})
+		if err == nil && res.ExitCode != 0 {
+			if ds.Optional {
+				return nil, nil
+			}
+			err = fmt.Errorf("command exited with code %d: %s", res.ExitCode,
+				strings.TrimSpace(string(res.Stderr)))
+		}
+		data = res.Stdout
+	default:
+		return nil, fmt.Errorf("file, command or check required")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var v any
+	switch format {
+	case FormatJson:
+		v, err = lookupJson(data, ds)
+	case FormatYaml:
+		v, err = lookupYaml(data, ds)
+	case "", FormatText:
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return nil, fmt.Errorf("invalid format '%s'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if v == nil && ds.Key != "" && !ds.Optional {
+		return nil, fmt.Errorf("key '%s' not found", ds.Key)
+	}
+	return v, nil
+}
+
+func lookupJson(data []byte, ds DataSource) (any, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if ds.Key == "" {
+		return v, nil
+	}
+	found, err, _ := shipjson.LookupJson(ds.Key, v)
+	return found, err
+}
+
+func lookupYaml(data []byte, ds DataSource) (any, error) {
+	n := yaml.Node{}
+	if err := yaml.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+	if ds.Key == "" {
+		var v any
+		err := n.Decode(&v)
+		return v, err
+	}
+
+	nodes, err := utils.LookupYamlPath(&n, ds.Key)
+	if err != nil || len(nodes) == 0 {
+		return nil, err
+	}
+	values := []any{}
+	for _, fn := range nodes {
+		var v any
+		if err := fn.Decode(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// checkResult provides the status, passes, warnings, errors and breaches of
+// another check's result.
+func (c *ExprCheck) checkResult(ds DataSource) (any, error) {
+	for _, r := range c.results {
+		if r.Name != ds.Check {
+			continue
+		}
+		breaches := []string{}
+		for _, b := range r.Breaches {
+			breaches = append(breaches, b.String())
+		}
+		return map[string]any{
+			"type":     r.CheckType,
+			"severity": r.Severity,
+			"status":   string(r.Status),
+			"passes":   r.Passes,
+			"warnings": r.Warnings,
+			"errors":   r.Errors,
+			"breaches": breaches,
+		}, nil
+	}
+	if ds.Optional {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("no result for check '%s'", ds.Check)
+}
+
+// Evaluate returns the truthiness of the expression for the variables.
+func Evaluate(expression string, vars map[string]any) (bool, error) {
+	expr, err := parseExpression(expression)
+	if err != nil {
+		return false, err
+	}
+	v := (&exec.Evaluator{
+		Config: gonja.DefaultConfig,
+		Environment: &exec.Environment{
+			Context:           gonja.DefaultContext.Inherit().Update(exec.NewContext(vars)),
+			Filters:           gonja.DefaultEnvironment.Filters,
+			Tests:             gonja.DefaultEnvironment.Tests,
+			ControlStructures: gonja.DefaultEnvironment.ControlStructures,
+			Methods:           gonja.DefaultEnvironment.Methods,
+		},
+		Loader: gonja.DefaultLoader,
+	}).Eval(expr)
+	if v.IsError() {
+		return false, errors.New(v.Error())
+	}
+	return v.IsTrue(), nil
+}
+
+// parseExpression parses a single expression with gonja's parser; the
+// tokens are validated first, so that template statements or anything
+// following the expression are rejected.
+func parseExpression(expression string) (nodes.Expression, error) {
+	cfg := gonja.DefaultConfig
+	all := []*tokens.Token{}
+	stream := tokens.Lex(cfg.VariableStartString+" "+expression+" "+cfg.VariableEndString, cfg)
+	for !stream.End() {
+		all = append(all, stream.Next())
+	}
+
+	toks := []*tokens.Token{}
+	var prev *tokens.Token
+	for i, tok := range all {
+		switch {
+		case tok.Type == tokens.Error:
+			return nil, fmt.Errorf("invalid expression: %s", tok.Val)
+		case i == 0 && tok.Type == tokens.VariableBegin:
+			continue
+		case i == len(all)-1 && tok.Type == tokens.VariableEnd:
+			continue
+		case tok.Type != tokens.Whitespace && !isExpressionToken(tok.Type):
+			return nil, fmt.Errorf("invalid expression: unexpected '%s'", tok.Val)
+		case tok.Type == tokens.In && prev != nil && prev.Type == tokens.Not:
+			// gonja evaluates `a not in b` as `a in b`, so it is rejected
+			// rather than giving a wrong result.
+			return nil, fmt.Errorf("invalid expression: use 'not (a in b)' instead of 'a not in b'")
+		}
+		if tok.Type != tokens.Whitespace {
+			prev = tok
+		}
+		toks = append(toks, tok)
+	}
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("invalid expression: empty")
+	}
+	toks = append(toks, &tokens.Token{Type: tokens.EOF})
+
+	p := parser.NewParser("expression", tokens.NewStream(toks), cfg, gonja.DefaultLoader, nil)
+	expr, err := p.ParseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if !p.End() {
+		return nil, fmt.Errorf("invalid expression: unexpected '%s'", p.Current().Val)
+	}
+	return expr, nil
+}
+
+// isExpressionToken reports whether the token type can be part of an
+// expression, as opposed to the template's data, statements or comments.
+func isExpressionToken(t tokens.Type) bool {
+	return t >= tokens.Addition && t <= tokens.Operator && t != tokens.Whitespace
+}
+
+// Render executes the template with the variables.
+func Render(tpl string, vars map[string]any) (string, error) {
+	t, err := gonja.FromString(tpl)
+	if err != nil {
+		return "", err
+	}
+	return t.ExecuteToString(exec.NewContext(vars))
+}
@@ -0,0 +1,222 @@
+package expr_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/checks/expr"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterChecks(t *testing.T) {
+	checksMap := map[config.CheckType]string{
+		Expr: "*expr.ExprCheck",
+	}
+	for ct, ts := range checksMap {
+		factory, ok := config.ChecksRegistry.Get(ct)
+		assert.True(t, ok)
+		c := factory()
+		ctype := reflect.TypeOf(c).String()
+		if ctype != ts {
+			t.Errorf("expecting check of type '%s', got '%s'", ts, ctype)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	c := ExprCheck{
+		CheckBase:  config.CheckBase{Name: "expr1"},
+		Data:       map[string]DataSource{"a": {File: "a.yml"}},
+		Expression: "a.foo",
+	}
+	err := c.Merge(&ExprCheck{
+		Data:    map[string]DataSource{"b": {File: "b.json"}},
+		Message: "foo is not set",
+	})
+	assert.NoError(err)
+	assert.EqualValues(ExprCheck{
+		CheckBase: config.CheckBase{Name: "expr1"},
+		Data: map[string]DataSource{
+			"a": {File: "a.yml"},
+			"b": {File: "b.json"},
+		},
+		Expression: "a.foo",
+		Message:    "foo is not set",
+	}, c)
+
+	err = c.Merge(&ExprCheck{CheckBase: config.CheckBase{Name: "expr2"}})
+	assert.Error(err, "can only merge checks with the same name")
+}
+
+func TestEvaluate(t *testing.T) {
+	assert := assert.New(t)
+
+	vars := map[string]any{
+		"ext":      map[string]any{"module": map[string]any{"redis": 0}},
+		"settings": map[string]any{"max_age": 300, "hosts": []any{"a", "b"}},
+		"msg":      "module is not in use {% if %}",
+	}
+	tests := map[string]bool{
+		"'redis' in ext.module":                         true,
+		"'memcache' in ext.module":                      false,
+		"settings.max_age >= 300":                       true,
+		"settings.hosts | length > 2":                   false,
+		"not ('redis' in ext.module) or settings.hosts": true,
+		"missing is defined":                            false,
+		"'not in' in msg and '{% if %}' in msg":         true,
+		"not ('c' in settings.hosts)":                   true,
+	}
+	for expression, expected := range tests {
+		ok, err := Evaluate(expression, vars)
+		assert.NoError(err, expression)
+		assert.Equal(expected, ok, expression)
+	}
+
+	_, err := Evaluate("settings.max_age +", vars)
+	assert.Error(err)
+
+	_, err = Evaluate("true %}{% include '/etc/passwd' %}{% if true", vars)
+	assert.EqualError(err, "invalid expression: unexpected '%}'")
+
+	_, err = Evaluate("true }} {{ false", vars)
+	assert.EqualError(err, "invalid expression: unexpected '}}'")
+
+	_, err = Evaluate("true false", vars)
+	assert.EqualError(err, "invalid expression: unexpected 'false'")
+
+	_, err = Evaluate("'redis' not in ext.module", vars)
+	assert.EqualError(err, "invalid expression: use 'not (a in b)' instead of 'a not in b'")
+}
+
+func runCheck(c *ExprCheck) *result.Result {
+	c.Init(Expr)
+	c.FetchData()
+	if c.HasData(true) && len(c.Result.Breaches) == 0 && len(c.Result.Errors) == 0 {
+		c.UnmarshalDataMap()
+		c.RunCheck()
+	}
+	c.Result.DetermineResultStatus(false)
+	return &c.Result
+}
+
+func TestExprCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "core.extension.yml"),
+		[]byte("module:\n  node: 0\n  redis: 0\n"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "redis.settings.yml"),
+		[]byte("connection:\n  host: ''\n"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "composer.json"),
+		[]byte(`{"require": {"php": ">=8.1"}}`), 0644))
+
+	newCheck := func(expression string, data map[string]DataSource) *ExprCheck {
+		c := &ExprCheck{Data: data, Expression: expression}
+		c.SetProjectDir(dir)
+		return c
+	}
+
+	t.Run("noExpression", func(t *testing.T) {
+		r := runCheck(newCheck("", nil))
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.Equal([]string{"expression required"}, r.Errors)
+	})
+
+	t.Run("files", func(t *testing.T) {
+		r := runCheck(newCheck("not ('redis' in ext.module) or redis.connection.host", map[string]DataSource{
+			"ext":   {File: "core.extension.yml"},
+			"redis": {File: "redis.settings.yml", Optional: true},
+		}))
+		assert.Equal(result.Fail, r.Status)
+
+		c := newCheck("not ('redis' in ext.module) or redis.connection.host", map[string]DataSource{
+			"ext":   {File: "core.extension.yml"},
+			"redis": {File: "redis.settings.yml"},
+		})
+		c.Message = "redis is enabled but its host is '{{ redis.connection.host }}'"
+		r = runCheck(c)
+		assert.Equal(result.Fail, r.Status)
+		assert.EqualValues([]result.Breach{&result.ValueBreach{
+			BreachType: result.BreachTypeValue,
+			CheckType:  "expr",
+			Severity:   "normal",
+			Value:      "redis is enabled but its host is ''",
+		}}, r.Breaches)
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		r := runCheck(newCheck("php == '>=8.1' and 'node' in modules", map[string]DataSource{
+			"php":     {File: "composer.json", Key: "$.require.php"},
+			"modules": {File: "core.extension.yml", Key: "module"},
+		}))
+		assert.Equal(result.Pass, r.Status)
+		assert.Equal([]string{"expression 'php == '>=8.1' and 'node' in modules' is true"}, r.Passes)
+	})
+
+	t.Run("missingData", func(t *testing.T) {
+		r := runCheck(newCheck("foo", map[string]DataSource{
+			"foo": {File: "foo.yml"},
+			"bar": {File: "composer.json", Key: "$.name"},
+		}))
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.Len(r.Errors, 2)
+
+		// The expression is not evaluated without its data; here it would
+		// otherwise be true.
+		c := newCheck("foo is none", map[string]DataSource{"foo": {File: "foo.yml"}})
+		c.Init(Expr)
+		c.FetchData()
+		assert.False(c.HasData(true))
+		assert.Empty(c.Result.Breaches)
+		assert.Len(c.Result.Errors, 1)
+
+		r = runCheck(newCheck("foo is none and bar is none", map[string]DataSource{
+			"foo": {File: "foo.yml", Optional: true},
+			"bar": {File: "composer.json", Key: "$.name", Optional: true},
+		}))
+		assert.Equal(result.Pass, r.Status)
+	})
+
+	t.Run("command", func(t *testing.T) {
+		r := runCheck(newCheck("out.version | int >= 8", map[string]DataSource{
+			"out": {Command: "echo '{\"version\": 8}'", Format: "json"},
+		}))
+		assert.Equal(result.Pass, r.Status)
+
+		r = runCheck(newCheck("out", map[string]DataSource{
+			"out": {Command: "echo oops >&2; exit 1"},
+		}))
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.EqualValues([]string{"data 'out': command exited with code 1: oops"}, r.Errors)
+	})
+
+	t.Run("checkResults", func(t *testing.T) {
+		assert.ElementsMatch([]string{"Illegal files"}, newCheck("files", map[string]DataSource{
+			"files": {Check: "Illegal files"},
+			"ext":   {File: "core.extension.yml"},
+		}).ResultsDependencies())
+
+		c := newCheck("files.status == 'Pass' and not files.breaches", map[string]DataSource{
+			"files": {Check: "Illegal files"},
+		})
+		c.SetResults([]result.Result{{Name: "Illegal files", Status: result.Pass}})
+		r := runCheck(c)
+		assert.Equal(result.Pass, r.Status)
+
+		c = newCheck("files.status == 'Pass'", map[string]DataSource{
+			"files": {Check: "Unknown"},
+		})
+		r = runCheck(c)
+		assert.Equal(result.Fail, r.Status)
+		assert.EqualValues([]string{"data 'files': no result for check 'Unknown'"}, r.Errors)
+	})
+}
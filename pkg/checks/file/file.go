@@ -4,9 +4,14 @@ import "github.com/salsadigitalauorg/shipshape/pkg/config"
 
 //go:generate go run ../../../cmd/gen.go registry --checkpackage=file
 
+// RegisterChecks registers the file and file-diff checks.
 func RegisterChecks() {
-	config.ChecksRegistry[File] = func() config.Check { return &FileCheck{} }
-	config.ChecksRegistry[FileDiff] = func() config.Check { return &FileDiffCheck{} }
+	config.MustRegister(File, func() config.Check { return &FileCheck{} }, config.Meta{
+		Description: "Checks a directory for disallowed files",
+	})
+	config.MustRegister(FileDiff, func() config.Check { return &FileDiffCheck{} }, config.Meta{
+		Description: "Compares a file with a source file or template",
+	})
 }
 
 func init() {
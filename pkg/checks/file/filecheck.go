@@ -41,11 +41,9 @@ func (c *FileCheck) RequiresData() bool { return false }
 // the provided regex ExcludePattern and skipping the list of provided relative
 // directories.
 func (c *FileCheck) RunCheck() {
-	files, err := utils.FindFiles(filepath.Join(config.ProjectDir, c.Path), c.DisallowedPattern, c.ExcludePattern, c.SkipDir)
+	files, err := utils.FindFiles(filepath.Join(c.GetProjectDir(), c.Path), c.DisallowedPattern, c.ExcludePattern, c.SkipDir)
 	if err != nil {
-		c.AddBreach(&result.ValueBreach{
-			ValueLabel: "error finding files",
-			Value:      err.Error()})
+		c.AddError("error finding files: " + err.Error())
 		return
 	}
 	if len(files) == 0 {
@@ -53,8 +51,17 @@ func (c *FileCheck) RunCheck() {
 		c.AddPass("No illegal files")
 		return
 	}
-	c.AddBreach(&result.KeyValuesBreach{
+	breach := &result.KeyValuesBreach{
 		Key:    "illegal files found",
 		Values: files,
-	})
+	}
+	// The breach can only point to a file when a single one was found.
+	if len(files) == 1 {
+		loc := files[0]
+		if rel, err := filepath.Rel(c.GetProjectDir(), loc); err == nil {
+			loc = rel
+		}
+		breach.Location = &result.Location{File: loc}
+	}
+	c.AddBreach(breach)
 }
@@ -58,17 +58,8 @@ func TestFileCheckRunCheck(t *testing.T) {
 	c.Result.DetermineResultStatus(false)
 	assert.Equal(result.Fail, c.Result.Status)
 	assert.Equal(0, len(c.Result.Passes))
-	assert.EqualValues(
-		[]result.Breach{&result.ValueBreach{
-			CheckType:  "file",
-			CheckName:  "filecheck1",
-			BreachType: result.BreachTypeValue,
-			Severity:   "normal",
-			ValueLabel: "error finding files",
-			Value:      "lstat testdata/file-non-existent: no such file or directory",
-		}},
-		c.Result.Breaches,
-	)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"error finding files: lstat testdata/file-non-existent: no such file or directory"}, c.Result.Errors)
 
 	c = FileCheck{
 		DisallowedPattern: "^(adminer|phpmyadmin|bigdump)?\\.php$",
@@ -96,6 +87,28 @@ func TestFileCheckRunCheck(t *testing.T) {
 		c.Result.Breaches,
 	)
 
+	c = FileCheck{
+		Path:              "sub",
+		DisallowedPattern: "^(adminer|phpmyadmin|bigdump)?\\.php$",
+	}
+	c.Name = "filecheck3"
+	c.Init(File)
+	c.RunCheck()
+	assert.EqualValues(
+		[]result.Breach{
+			&result.KeyValuesBreach{
+				BreachType: "key-values",
+				CheckType:  "file",
+				CheckName:  "filecheck3",
+				Severity:   "normal",
+				Key:        "illegal files found",
+				Values:     []string{"testdata/sub/phpmyadmin.php"},
+				Location:   &result.Location{File: "sub/phpmyadmin.php"},
+			},
+		},
+		c.Result.Breaches,
+	)
+
 	c = FileCheck{
 		Path:              "correct",
 		DisallowedPattern: "^(adminer|phpmyadmin|bigdump)?\\.php$",
@@ -50,7 +50,7 @@ func (c *FileDiffCheck) FetchData() {
 	c.DataMap = map[string][]byte{}
 	var err error
 	// Fetch the target file.
-	c.DataMap["target"], err = os.ReadFile(filepath.Join(config.ProjectDir, c.TargetFile))
+	c.DataMap["target"], err = os.ReadFile(filepath.Join(c.GetProjectDir(), c.TargetFile))
 	if err != nil {
 		// No failure if missing file and ignoring missing.
 		var pathError *fs.PathError
@@ -59,9 +59,7 @@ func (c *FileDiffCheck) FetchData() {
 			c.Result.Status = result.Pass
 			return
 		} else {
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: "error reading target file: " + c.TargetFile,
-				Value:      err.Error()})
+			c.AddError("error reading target file: " + c.TargetFile + ": " + err.Error())
 			return
 		}
 	}
@@ -70,13 +68,11 @@ func (c *FileDiffCheck) FetchData() {
 	if utils.StringIsUrl(c.SourceFile) {
 		c.DataMap["source"], err = utils.FetchContentFromUrl(c.SourceFile)
 	} else {
-		c.DataMap["source"], err = os.ReadFile(filepath.Join(config.ProjectDir, c.SourceFile))
+		c.DataMap["source"], err = os.ReadFile(filepath.Join(c.GetProjectDir(), c.SourceFile))
 	}
 
 	if err != nil {
-		c.AddBreach(&result.ValueBreach{
-			ValueLabel: "error fetching source file: " + c.SourceFile,
-			Value:      err.Error()})
+		c.AddError("error fetching source file: " + c.SourceFile + ": " + err.Error())
 		return
 	}
 
@@ -84,18 +80,14 @@ func (c *FileDiffCheck) FetchData() {
 	if c.SourceContext != nil && len(c.SourceContext) > 0 {
 		jinjaTemplate, jinjaErr := gonja.FromBytes(c.DataMap["source"])
 		if jinjaErr != nil {
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: "error parsing source file: " + c.SourceFile,
-				Value:      jinjaErr.Error()})
+			c.AddError("error parsing source file: " + c.SourceFile + ": " + jinjaErr.Error())
 			return
 		}
 
 		jinjaContext := exec.NewContext(c.SourceContext)
 		c.DataMap["source"], jinjaErr = jinjaTemplate.ExecuteToBytes(jinjaContext)
 		if jinjaErr != nil {
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: "error compiling source file with source context: " + c.SourceFile,
-				Value:      jinjaErr.Error()})
+			c.AddError("error compiling source file with source context: " + c.SourceFile + ": " + jinjaErr.Error())
 			return
 		}
 	}
@@ -143,6 +135,7 @@ func (c *FileDiffCheck) RunCheck() {
 	} else {
 		c.AddBreach(&result.ValueBreach{
 			ValueLabel: fmt.Sprintf("Target file %s is different from Source file %s", c.TargetFile, c.SourceFile),
-			Value:      fmt.Sprintf("diff: \n%s", diff)})
+			Value:      fmt.Sprintf("diff: \n%s", diff),
+			Location:   &result.Location{File: c.TargetFile}})
 	}
 }
@@ -124,17 +124,8 @@ func TestFileDiffCheck_FetchData(t *testing.T) {
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
 		assertions.Equal(0, len(c.Result.Passes))
-		assertions.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				CheckType:  "filediff",
-				CheckName:  "filediffcheck1",
-				BreachType: result.BreachTypeValue,
-				Severity:   "normal",
-				ValueLabel: "error fetching source file: file0.txt",
-				Value:      "open testdata/filediff/file0.txt: no such file or directory",
-			}},
-			c.Result.Breaches,
-		)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"error fetching source file: file0.txt: open testdata/filediff/file0.txt: no such file or directory"}, c.Result.Errors)
 	})
 
 	t.Run("failOnTargetNotExist", func(t *testing.T) {
@@ -148,17 +139,8 @@ func TestFileDiffCheck_FetchData(t *testing.T) {
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
 		assertions.Equal(0, len(c.Result.Passes))
-		assertions.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				CheckType:  "filediff",
-				CheckName:  "filediffcheck1",
-				BreachType: result.BreachTypeValue,
-				Severity:   "normal",
-				ValueLabel: "error reading target file: file0.txt",
-				Value:      "open testdata/filediff/file0.txt: no such file or directory",
-			}},
-			c.Result.Breaches,
-		)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"error reading target file: file0.txt: open testdata/filediff/file0.txt: no such file or directory"}, c.Result.Errors)
 	})
 
 	t.Run("passOnIgnoreMissingTarget", func(t *testing.T) {
@@ -188,17 +170,8 @@ func TestFileDiffCheck_FetchData(t *testing.T) {
 		c.Result.DetermineResultStatus(false)
 		assertions.Equal(result.Fail, c.Result.Status)
 		assertions.Equal(0, len(c.Result.Passes))
-		assertions.EqualValues(
-			[]result.Breach{&result.ValueBreach{
-				CheckType:  "filediff",
-				CheckName:  "filediffcheck1",
-				BreachType: result.BreachTypeValue,
-				Severity:   "normal",
-				ValueLabel: "error parsing source file: file3.txt",
-				Value:      "failed to parse template 'This is file #{{ VERSION }.\n': '}}' expected here (Line: 0 Col: 0, near \"Unexpected delimiter \"}\"\")",
-			}},
-			c.Result.Breaches,
-		)
+		assertions.Empty(c.Result.Breaches)
+		assertions.Equal([]string{"error parsing source file: file3.txt: failed to parse template 'This is file #{{ VERSION }.\n': '}}' expected here (Line: 0 Col: 0, near \"Unexpected delimiter \"}\"\")"}, c.Result.Errors)
 	})
 }
 
@@ -242,6 +215,7 @@ func TestFileDiffCheck_RunCheck(t *testing.T) {
 				Severity:   "normal",
 				ValueLabel: "Target file file2.txt is different from Source file file1.txt",
 				Value:      "diff: \n--- file1.txt\n+++ file2.txt\n@@ -1 +1 @@\n-This is file #1.\n+This is file #2.\n",
+				Location:   &result.Location{File: "file2.txt"},
 			}},
 			c.Result.Breaches,
 		)
@@ -284,6 +258,7 @@ func TestFileDiffCheck_RunCheck(t *testing.T) {
 				Severity:   "normal",
 				ValueLabel: "Target file file2.txt is different from Source file file4.txt",
 				Value:      "diff: \n--- file4.txt\n+++ file2.txt\n@@ -1 +1 @@\n-This is file #1.\n+This is file #2.\n",
+				Location:   &result.Location{File: "file2.txt"},
 			}},
 			c.Result.Breaches,
 		)
@@ -5,6 +5,8 @@ package json
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/goccy/go-json"
 	"github.com/salsadigitalauorg/shipshape/pkg/checks/yaml"
@@ -21,7 +23,7 @@ func (c *JsonCheck) UnmarshalDataMap() {
 		var n any
 		err := json.Unmarshal(data, &n)
 		if err != nil {
-			c.AddBreach(&result.ValueBreach{ValueLabel: "JSON error", Value: err.Error()})
+			c.AddError("JSON error: " + err.Error())
 			return
 		}
 		c.Node[configName] = n
@@ -33,24 +35,31 @@ func (c *JsonCheck) UnmarshalDataMap() {
 // Fail messages of the Check Result.
 func (c *JsonCheck) processData(configName string) {
 	for _, kv := range c.KeyValues {
+		kv, err := ResolveValueFrom(c.Node[configName], kv, c.GetProjectDir())
+		if err != nil {
+			c.AddError(err.Error())
+			continue
+		}
 		kvr, fails, err := CheckKeyValue(c.Node[configName], kv)
 		switch kvr {
 		case yaml.KeyValueError:
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 		case yaml.KeyValueNotFound:
 			c.AddBreach(&result.KeyValueBreach{
 				KeyLabel:   "config",
 				Key:        configName,
 				ValueLabel: "key not found",
 				Value:      kv.Key,
+				Location:   c.FileLocation(configName),
 			})
 		case yaml.KeyValueNotEqual:
 			c.AddBreach(&result.KeyValueBreach{
 				KeyLabel:      configName,
 				Key:           kv.Key,
 				ValueLabel:    "actual",
-				ExpectedValue: kv.Value,
-				Value:         fails[0],
+				ExpectedValue: kv.Expected(),
+				Value:         kv.Actual(fails),
+				Location:      c.FileLocation(configName),
 			})
 		case yaml.KeyValueDisallowedFound:
 			c.AddBreach(&result.KeyValuesBreach{
@@ -58,12 +67,13 @@ func (c *JsonCheck) processData(configName string) {
 				Key:        configName,
 				ValueLabel: fmt.Sprintf("disallowed %s", kv.Key),
 				Values:     fails,
+				Location:   c.FileLocation(configName),
 			})
 		case yaml.KeyValueEqual:
-			if kv.IsList {
+			if kv.IsList && !kv.HasOperator() {
 				c.AddPass(fmt.Sprintf("[%s] no disallowed '%s'", configName, kv.Key))
 			} else {
-				c.AddPass(fmt.Sprintf("[%s] '%s' equals '%s'", configName, kv.Key, kv.Value))
+				c.AddPass(fmt.Sprintf("[%s] '%s' %s", configName, kv.Key, kv.Condition()))
 			}
 		}
 	}
@@ -74,14 +84,64 @@ func (c *JsonCheck) processData(configName string) {
 	}
 }
 
+// ResolveValueFrom sets the Value of the KeyValue from its ValueFrom, if any,
+// looking the key up in the file referenced or else in the node provided.
+func ResolveValueFrom(node any, kv KeyValue, projectDir string) (KeyValue, error) {
+	resolved, err := yaml.ResolveValueFromWith(node, kv.KeyValue, projectDir, json.Unmarshal,
+		func(node any, key string) ([]string, bool, error) {
+			found, err, _ := LookupJson(key, node)
+			if err != nil {
+				return nil, false, err
+			}
+			switch v := found.(type) {
+			case nil:
+				return nil, false, nil
+			case []any:
+				// A list is never a scalar, even with a single value.
+				values := make([]string, len(v))
+				for i, e := range v {
+					values[i] = fmt.Sprint(e)
+				}
+				return values, false, nil
+			case map[string]any:
+				return []string{fmt.Sprint(v)}, false, nil
+			}
+			return []string{fmt.Sprint(found)}, true, nil
+		})
+	kv.KeyValue = resolved
+	return kv, err
+}
+
 // CheckKeyValue lookups the Json data for a specific KeyValue and returns the
 // result, actual values and errors.
 func CheckKeyValue(node any, kv KeyValue) (yaml.KeyValueResult, []string, error) {
-	foundValues, err, _ := LookupJson(kv.Key, node)
+	if err := kv.ValidateOperator(); err != nil {
+		return yaml.KeyValueError, nil, err
+	}
+
+	data := node
+	if kv.Operator == yaml.OpAbsent || kv.Operator == yaml.OpExists {
+		// A key set to null exists.
+		data = markNulls(node)
+	}
+	foundValues, err, _ := LookupJson(kv.Key, data)
 	if err != nil {
 		return yaml.KeyValueError, nil, err
 	}
 
+	switch kv.Operator {
+	case yaml.OpAbsent:
+		if foundValues != nil {
+			return yaml.KeyValueNotEqual, []string{"present"}, nil
+		}
+		return yaml.KeyValueEqual, nil, nil
+	case yaml.OpExists:
+		if foundValues == nil {
+			return yaml.KeyValueNotFound, nil, nil
+		}
+		return yaml.KeyValueEqual, nil, nil
+	}
+
 	if foundValues == nil {
 		if !kv.Optional {
 			return yaml.KeyValueNotFound, nil, nil
@@ -89,6 +149,10 @@ func CheckKeyValue(node any, kv KeyValue) (yaml.KeyValueResult, []string, error)
 		return yaml.KeyValueEqual, nil, nil
 	}
 
+	if kv.HasOperator() && len(kv.AllowedValues) == 0 && len(kv.DisallowedValues) == 0 {
+		return checkOperator(foundValues, kv)
+	}
+
 	// Throw an error if we are checking a list but no allow/disallow list provided.
 	if len(kv.AllowedValues) == 0 && len(kv.DisallowedValues) == 0 && kv.IsList {
 		return yaml.KeyValueError, nil, errors.New("list of allowed or disallowed values not provided")
@@ -132,3 +196,60 @@ func CheckKeyValue(node any, kv KeyValue) (yaml.KeyValueResult, []string, error)
 	}
 	return yaml.KeyValueEqual, nil, nil
 }
+
+// checkOperator applies the KeyValue's operator to the values found.
+func checkOperator(foundValues any, kv KeyValue) (yaml.KeyValueResult, []string, error) {
+	switch kv.Operator {
+	case yaml.OpLength:
+		var length int
+		switch v := foundValues.(type) {
+		case []any:
+			length = len(v)
+		case map[string]any:
+			length = len(v)
+		default:
+			return yaml.KeyValueError, nil, fmt.Errorf("'%s' is not a list or map", kv.Key)
+		}
+		ok, err := kv.CompareLength(length)
+		if err != nil {
+			return yaml.KeyValueError, nil, err
+		}
+		if !ok {
+			return yaml.KeyValueNotEqual, []string{strconv.Itoa(length)}, nil
+		}
+		return yaml.KeyValueEqual, nil, nil
+	case yaml.OpContains:
+		if list, ok := foundValues.([]any); ok {
+			values := []string{}
+			for _, item := range list {
+				if strings.EqualFold(fmt.Sprint(item), kv.Value) {
+					return yaml.KeyValueEqual, nil, nil
+				}
+				values = append(values, fmt.Sprint(item))
+			}
+			return yaml.KeyValueNotEqual, []string{strings.Join(values, ", ")}, nil
+		}
+	}
+
+	items := []any{foundValues}
+	if list, ok := foundValues.([]any); ok {
+		if !kv.IsList {
+			return yaml.KeyValueError, nil, errors.New("A list of values was found but is-list is not set")
+		}
+		items = list
+	}
+	var fails []string
+	for _, item := range items {
+		ok, err := kv.Compare(fmt.Sprint(item))
+		if err != nil {
+			return yaml.KeyValueError, nil, err
+		}
+		if !ok && !utils.SliceContains(fails, fmt.Sprint(item)) {
+			fails = append(fails, fmt.Sprint(item))
+		}
+	}
+	if len(fails) > 0 {
+		return yaml.KeyValueNotEqual, fails, nil
+	}
+	return yaml.KeyValueEqual, nil, nil
+}
@@ -7,7 +7,6 @@ import (
 	. "github.com/salsadigitalauorg/shipshape/pkg/checks/json"
 	"github.com/salsadigitalauorg/shipshape/pkg/checks/yaml"
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
-	"github.com/salsadigitalauorg/shipshape/pkg/result"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,16 +32,8 @@ func TestJsonCheckUnmarshalDataMap(t *testing.T) {
 
 	c.UnmarshalDataMap()
 	assertions.EqualValues(0, len(c.Result.Passes))
-	assertions.ElementsMatch(
-		[]result.Breach{
-			&result.ValueBreach{
-				BreachType: result.BreachTypeValue,
-				ValueLabel: "JSON error",
-				Value:      "invalid character 'p' looking for beginning of value",
-			},
-		},
-		c.Result.Breaches,
-	)
+	assertions.Empty(c.Result.Breaches)
+	assertions.Equal([]string{"JSON error: invalid character 'p' looking for beginning of value"}, c.Result.Errors)
 
 	// Valid data.
 	c = JsonCheck{
@@ -415,3 +406,102 @@ func TestJsonCheckKeyValue(t *testing.T) {
 	}
 
 }
+
+func TestResolveValueFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	var node any
+	err := json.Unmarshal([]byte(`{"license": "MIT", "version": 2, "authors": ["a", "b"], "extra": {"a": 1}}`), &node)
+	assert.NoError(err)
+
+	// No reference.
+	kv, err := ResolveValueFrom(node, KeyValue{KeyValue: yaml.KeyValue{Key: "$.license", Value: "MIT"}}, "testdata")
+	assert.NoError(err)
+	assert.Equal("MIT", kv.Value)
+
+	// Same data.
+	kv, err = ResolveValueFrom(node, KeyValue{KeyValue: yaml.KeyValue{
+		Key:       "$.license",
+		ValueFrom: &yaml.ValueFrom{Key: "$.version"},
+	}}, "testdata")
+	assert.NoError(err)
+	assert.Equal("2", kv.Value)
+
+	// Another file.
+	kv, err = ResolveValueFrom(node, KeyValue{KeyValue: yaml.KeyValue{
+		Key:       "$.license",
+		ValueFrom: &yaml.ValueFrom{File: "composer.map.json", Key: "$.license"},
+	}}, "testdata")
+	assert.NoError(err)
+	assert.Equal("MIT", kv.Value)
+
+	tests := map[string]yaml.ValueFrom{
+		"value-from key required for '$.license'":                    {File: "composer.map.json"},
+		"value-from key '$.foo' not found in composer.map.json":      {File: "composer.map.json", Key: "$.foo"},
+		"value-from key '$.authors' matches multiple values in data": {Key: "$.authors"},
+		"value-from key '$.extra' is not a scalar in data":           {Key: "$.extra"},
+	}
+	for expectedErr, vf := range tests {
+		vf := vf
+		_, err = ResolveValueFrom(node, KeyValue{KeyValue: yaml.KeyValue{Key: "$.license", ValueFrom: &vf}}, "testdata")
+		assert.EqualError(err, expectedErr)
+	}
+
+	_, err = ResolveValueFrom(node, KeyValue{KeyValue: yaml.KeyValue{
+		Key:       "$.license",
+		Value:     "MIT",
+		ValueFrom: &yaml.ValueFrom{Key: "$.version"},
+	}}, "testdata")
+	assert.EqualError(err, "value and value-from are mutually exclusive for '$.license'")
+}
+
+func TestJsonCheckKeyValueOperators(t *testing.T) {
+	assert := assert.New(t)
+
+	var node any
+	err := json.Unmarshal([]byte(`{
+	"require": {"php": "8.2.1", "drupal/core": "10.1.0"},
+	"extra": {"max_age": 180},
+	"authors": ["alice", "bob"],
+	"keywords": [],
+	"homepage": null
+}`), &node)
+	assert.NoError(err)
+
+	kv := func(key string, op yaml.Operator, value string) KeyValue {
+		return KeyValue{KeyValue: yaml.KeyValue{Key: key, Operator: op, Value: value}}
+	}
+	tests := []struct {
+		kv            KeyValue
+		expected      yaml.KeyValueResult
+		expectedFails []string
+	}{
+		{kv("$.extra.max_age", yaml.OpGte, "300"), yaml.KeyValueNotEqual, []string{"180"}},
+		{kv("$.extra.max_age", yaml.OpLt, "300"), yaml.KeyValueEqual, nil},
+		{kv("$.require.php", yaml.OpSemver, ">=8.1"), yaml.KeyValueEqual, nil},
+		{kv("$.require.php", yaml.OpNotEquals, "8.2.1"), yaml.KeyValueNotEqual, []string{"8.2.1"}},
+		{kv("$.require", yaml.OpLength, "2"), yaml.KeyValueEqual, nil},
+		{kv("$.keywords", yaml.OpLength, ">=1"), yaml.KeyValueNotEqual, []string{"0"}},
+		{kv("$.authors", yaml.OpContains, "Bob"), yaml.KeyValueEqual, nil},
+		{kv("$.authors", yaml.OpContains, "carol"), yaml.KeyValueNotEqual, []string{"alice, bob"}},
+		{kv("$.require['drupal/core']", yaml.OpRegex, `^10\.`), yaml.KeyValueEqual, nil},
+		{kv("$.license", yaml.OpExists, ""), yaml.KeyValueNotFound, nil},
+		{kv("$.license", yaml.OpAbsent, ""), yaml.KeyValueEqual, nil},
+		{kv("$.require.php", yaml.OpAbsent, ""), yaml.KeyValueNotEqual, []string{"present"}},
+		{kv("$.homepage", yaml.OpExists, ""), yaml.KeyValueEqual, nil},
+		{kv("$.homepage", yaml.OpAbsent, ""), yaml.KeyValueNotEqual, []string{"present"}},
+		{kv("homepage", yaml.OpExists, ""), yaml.KeyValueEqual, nil},
+		{kv("license", yaml.OpExists, ""), yaml.KeyValueNotFound, nil},
+	}
+	for _, tt := range tests {
+		kvr, fails, err := CheckKeyValue(node, tt.kv)
+		assert.NoError(err, tt.kv.Key)
+		assert.Equal(tt.expected, kvr, "%s %s", tt.kv.Key, tt.kv.Condition())
+		assert.Equal(tt.expectedFails, fails, "%s %s", tt.kv.Key, tt.kv.Condition())
+	}
+
+	_, _, err = CheckKeyValue(node, kv("$.require.php", yaml.OpLength, "1"))
+	assert.EqualError(err, "'$.require.php' is not a list or map")
+	_, _, err = CheckKeyValue(node, kv("$.authors", yaml.OpRegex, "^a"))
+	assert.EqualError(err, "A list of values was found but is-list is not set")
+}
@@ -4,8 +4,13 @@ import (
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 )
 
+// RegisterChecks registers the json check.
 func RegisterChecks() {
-	config.ChecksRegistry[Json] = func() config.Check { return &JsonCheck{} }
+	config.MustRegister(Json, func() config.Check { return &JsonCheck{} }, config.Meta{
+		Description: "Checks JSON files for the presence or absence of required/disallowed values",
+		// The values of the embedded yaml check are not used.
+		Fields: []string{"path", "file", "files", "pattern", "exclude-pattern", "ignore-missing", "key-values"},
+	})
 }
 
 func init() {
@@ -97,18 +97,8 @@ func TestJsonCheckFetchData(t *testing.T) {
 	c.File = "non-existent.json"
 	c.FetchData()
 	assertions.Empty(c.Result.Passes)
-	assertions.ElementsMatch(
-		[]result.Breach{
-			&result.ValueBreach{
-				CheckType:  "json",
-				Severity:   "normal",
-				BreachType: result.BreachTypeValue,
-				ValueLabel: "error reading file: testdata/non-existent.json",
-				Value:      "open testdata/non-existent.json: no such file or directory",
-			},
-		},
-		c.Result.Breaches,
-	)
+	assertions.Empty(c.Result.Breaches)
+	assertions.Equal([]string{"error reading file: testdata/non-existent.json: open testdata/non-existent.json: no such file or directory"}, c.Result.Errors)
 
 	// Non-existent file with ignore missing.
 	c = MockJsonCheck()
@@ -124,16 +114,8 @@ func TestJsonCheckFetchData(t *testing.T) {
 	c.Path = ""
 	c.FetchData()
 	assertions.Empty(c.Result.Passes)
-	assertions.ElementsMatch(
-		[]result.Breach{
-			&result.ValueBreach{
-				BreachType: result.BreachTypeValue,
-				ValueLabel: "error finding files in path: testdata",
-				Value:      "error parsing regexp: missing argument to repetition operator: `*`",
-			},
-		},
-		c.Result.Breaches,
-	)
+	assertions.Empty(c.Result.Breaches)
+	assertions.Equal([]string{"error finding files in path: testdata: error parsing regexp: missing argument to repetition operator: `*`"}, c.Result.Errors)
 
 	// File pattern with no matching files.
 	c = MockJsonCheck()
@@ -210,6 +192,7 @@ func TestJsonCheckRunCheck(t *testing.T) {
 				ValueLabel:    "actual",
 				Value:         "BSD",
 				ExpectedValue: "MIT",
+				Location:      &result.Location{File: "composer.array.json"},
 			},
 			&result.KeyValueBreach{
 				BreachType:    result.BreachTypeKeyValue,
@@ -218,6 +201,7 @@ func TestJsonCheckRunCheck(t *testing.T) {
 				ValueLabel:    "actual",
 				Value:         "BSD",
 				ExpectedValue: "MIT",
+				Location:      &result.Location{File: "dir/composer.array.json"},
 			},
 			&result.KeyValueBreach{
 				BreachType:    result.BreachTypeKeyValue,
@@ -226,6 +210,7 @@ func TestJsonCheckRunCheck(t *testing.T) {
 				ValueLabel:    "actual",
 				Value:         "BSD",
 				ExpectedValue: "MIT",
+				Location:      &result.Location{File: "dir/subdir/composer.array.json"},
 			},
 		},
 		c.Result.Breaches,
@@ -260,6 +245,7 @@ func TestJsonCheckRunCheck(t *testing.T) {
 				Key:        "composer.map.json",
 				ValueLabel: "disallowed $.license",
 				Values:     []string{"MIT"},
+				Location:   &result.Location{File: "composer.map.json"},
 			},
 		},
 		c.Result.Breaches)
@@ -293,6 +279,7 @@ func TestJsonCheckRunCheck(t *testing.T) {
 				Key:        "composer.map.json",
 				ValueLabel: "disallowed $.license",
 				Values:     []string{"MIT"},
+				Location:   &result.Location{File: "composer.map.json"},
 			},
 		},
 		c.Result.Breaches)
@@ -316,16 +303,11 @@ func TestJsonCheckRunCheck(t *testing.T) {
 	assertions.True(c.HasData(false))
 	c.UnmarshalDataMap()
 	c.RunCheck()
+	c.Result.DetermineResultStatus(false)
 	assertions.Equal(result.Fail, c.Result.Status)
 	assertions.Empty(c.Result.Passes)
-	assertions.ElementsMatch(
-		[]result.Breach{
-			&result.ValueBreach{
-				BreachType: result.BreachTypeValue,
-				Value:      "json: invalid path format: found invalid path character * after dot",
-			},
-		},
-		c.Result.Breaches)
+	assertions.Empty(c.Result.Breaches)
+	assertions.Equal([]string{"json: invalid path format: found invalid path character * after dot"}, c.Result.Errors)
 
 	// Test non-existent key value.
 	c = JsonCheck{
@@ -354,6 +336,7 @@ func TestJsonCheckRunCheck(t *testing.T) {
 				Key:        "composer.map.json",
 				ValueLabel: "key not found",
 				Value:      "$.authors",
+				Location:   &result.Location{File: "composer.map.json"},
 			},
 		},
 		c.Result.Breaches)
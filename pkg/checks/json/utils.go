@@ -53,3 +53,29 @@ func LookupJson(path string, unmarshalledJsonData any) (foundValues any, err err
 
 	return foundValues, err, pathType
 }
+
+// jsonNull stands for a null value in the data, to tell it apart from a
+// missing key when looking it up.
+type jsonNull struct{}
+
+// markNulls returns a copy of the data in which null values are replaced
+// with jsonNull.
+func markNulls(data any) any {
+	switch v := data.(type) {
+	case nil:
+		return jsonNull{}
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = markNulls(e)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, e := range v {
+			l[i] = markNulls(e)
+		}
+		return l
+	}
+	return data
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
@@ -49,8 +50,11 @@ type PhpStanResult struct {
 	Errors []string `json:"errors"`
 }
 
+// RegisterChecks registers the phpstan check.
 func RegisterChecks() {
-	config.ChecksRegistry[PhpStan] = func() config.Check { return &PhpStanCheck{} }
+	config.MustRegister(PhpStan, func() config.Check { return &PhpStanCheck{} }, config.Meta{
+		Description: "Runs PHPStan and reports the errors found",
+	})
 }
 
 func init() {
@@ -74,7 +78,7 @@ func (c *PhpStanCheck) Merge(mergeCheck config.Check) error {
 
 func (c *PhpStanCheck) GetBinary() (path string) {
 	if len(c.Bin) == 0 {
-		path = filepath.Join(config.ProjectDir, PhpstanDefaultPath)
+		path = filepath.Join(c.GetProjectDir(), PhpstanDefaultPath)
 	} else {
 		path = c.Bin
 	}
@@ -88,7 +92,7 @@ func (c *PhpStanCheck) FetchData() {
 
 	configPath := c.Config
 	if !filepath.IsAbs(c.Config) {
-		configPath = filepath.Join(config.ProjectDir, configPath)
+		configPath = filepath.Join(c.GetProjectDir(), configPath)
 	}
 
 	args := []string{
@@ -101,7 +105,7 @@ func (c *PhpStanCheck) FetchData() {
 	for _, p := range c.Paths {
 		path := p
 		if !filepath.IsAbs(path) {
-			path = filepath.Join(config.ProjectDir, p)
+			path = filepath.Join(c.GetProjectDir(), p)
 		}
 		if _, err := os.Stat(path); !os.IsNotExist(err) {
 			foundPath = true
@@ -119,13 +123,9 @@ func (c *PhpStanCheck) FetchData() {
 	c.DataMap["phpstan"], err = command.ShellCommander(phpstanPath, args...).Output()
 	if err != nil {
 		if pathErr, ok := err.(*fs.PathError); ok {
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: pathErr.Path,
-				Value:      pathErr.Err.Error()})
+			c.AddError(pathErr.Path + ": " + pathErr.Err.Error())
 		} else if len(c.DataMap["phpstan"]) == 0 { // If errors were found, exit code will be 1.
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: "Phpstan failed to run",
-				Value:      string(err.(*exec.ExitError).Stderr)})
+			c.AddError("Phpstan failed to run: " + string(err.(*exec.ExitError).Stderr))
 		}
 	}
 }
@@ -134,7 +134,7 @@ func (c *PhpStanCheck) FetchData() {
 // directory for phpstan to scan.
 func (c *PhpStanCheck) HasData(failCheck bool) bool {
 	if c.DataMap == nil && len(c.Result.Passes) == 0 {
-		if failCheck {
+		if failCheck && len(c.Result.Errors) == 0 {
 			c.AddBreach(&result.ValueBreach{Value: "no data available"})
 		}
 		return false
@@ -158,9 +158,7 @@ func (c *PhpStanCheck) UnmarshalDataMap() {
 	c.phpstanResult = PhpStanResult{}
 	err := json.Unmarshal(c.DataMap["phpstan"], &c.phpstanResult)
 	if err != nil {
-		c.AddBreach(&result.ValueBreach{
-			ValueLabel: "unable to parse phpstan result",
-			Value:      err.Error()})
+		c.AddError("unable to parse phpstan result: " + err.Error())
 		return
 	}
 
@@ -172,15 +170,15 @@ func (c *PhpStanCheck) UnmarshalDataMap() {
 	// Unmarshal file errors.
 	err = json.Unmarshal(c.phpstanResult.FilesRaw, &c.phpstanResult.Files)
 	if err != nil {
-		c.AddBreach(&result.ValueBreach{
-			ValueLabel: "unable to parse phpstan file errors",
-			Value:      err.Error()})
+		c.AddError("unable to parse phpstan file errors: " + err.Error())
 		return
 	}
 }
 
 // RunCheck processes the parsed data and populates the errors, if any.
 func (c *PhpStanCheck) RunCheck() {
+	c.AddFact("errors", strconv.Itoa(c.phpstanResult.Totals.Errors))
+	c.AddFact("file errors", strconv.Itoa(c.phpstanResult.Totals.FileErrors))
 	if c.phpstanResult.Totals.Errors == 0 && c.phpstanResult.Totals.FileErrors == 0 {
 		c.AddPass("no error found")
 		c.Result.Status = result.Pass
@@ -194,9 +192,17 @@ func (c *PhpStanCheck) RunCheck() {
 				fmt.Sprintf("line %d: %s", er.Line,
 					strings.ReplaceAll(er.Message, "\n", "")))
 		}
+		loc := &result.Location{File: file}
+		if relFile, err := filepath.Rel(c.GetProjectDir(), file); err == nil && !strings.HasPrefix(relFile, "..") {
+			loc.File = relFile
+		}
+		if len(errors.Messages) > 0 {
+			loc.Line = errors.Messages[0].Line
+		}
 		c.AddBreach(&result.KeyValuesBreach{
-			Key:    fmt.Sprintf("file: %s", file),
-			Values: errLines,
+			Key:      fmt.Sprintf("file: %s", file),
+			Values:   errLines,
+			Location: loc,
 		})
 	}
 
@@ -19,7 +19,9 @@ func TestRegisterChecks(t *testing.T) {
 		PhpStan: "*phpstan.PhpStanCheck",
 	}
 	for ct, ts := range checksMap {
-		c := config.ChecksRegistry[ct]()
+		factory, ok := config.ChecksRegistry.Get(ct)
+		assert.True(t, ok)
+		c := factory()
 		ctype := reflect.TypeOf(c).String()
 		if ctype != ts {
 			t.Errorf("expecting check of type '%s', got '%s'", ts, ctype)
@@ -128,14 +130,8 @@ func TestFetchDataBinNotExists(t *testing.T) {
 		Paths:  []string{dir},
 	}
 	c.FetchData()
-	assert.EqualValues(
-		[]result.Breach{&result.ValueBreach{
-			BreachType: "value",
-			ValueLabel: "Phpstan failed to run",
-			Value:      "/my/custom/path/phpstan: no such file or directory",
-		}},
-		c.Result.Breaches,
-	)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"Phpstan failed to run: /my/custom/path/phpstan: no such file or directory"}, c.Result.Errors)
 }
 
 func TestFetchDataBinExists(t *testing.T) {
@@ -182,6 +178,14 @@ func TestHasData(t *testing.T) {
 		)
 	})
 
+	t.Run("no data, with errors", func(t *testing.T) {
+		assert := assert.New(t)
+		c := PhpStanCheck{}
+		c.AddError("Phpstan failed to run: oops")
+		assert.False(c.HasData(true))
+		assert.Empty(c.Result.Breaches)
+	})
+
 	t.Run("no data, but passed", func(t *testing.T) {
 		assert := assert.New(t)
 		c := PhpStanCheck{}
@@ -224,17 +228,15 @@ func TestUnmarshalDataMap(t *testing.T) {
 		},
 	}
 	c.UnmarshalDataMap()
+	assert.Empty(c.Result.Breaches)
 	assert.EqualValues(
-		[]result.Breach{&result.ValueBreach{
-			BreachType: "value",
-			ValueLabel: "unable to parse phpstan file errors",
-			Value: "json: cannot unmarshal array into Go value of type " +
-				"map[string]struct { Errors int \"json:\\\"errors\\\"\"; Messages " +
-				"[]struct { Message string \"json:\\\"message\\\"\"; Line int \"json:" +
-				"\\\"line\\\"\"; Ignorable bool \"json:\\\"ignorable\\\"\" } \"json:" +
-				"\\\"messages\\\"\" }",
-		}},
-		c.Result.Breaches,
+		[]string{"unable to parse phpstan file errors: " +
+			"json: cannot unmarshal array into Go value of type " +
+			"map[string]struct { Errors int \"json:\\\"errors\\\"\"; Messages " +
+			"[]struct { Message string \"json:\\\"message\\\"\"; Line int \"json:" +
+			"\\\"line\\\"\"; Ignorable bool \"json:\\\"ignorable\\\"\" } \"json:" +
+			"\\\"messages\\\"\" }"},
+		c.Result.Errors,
 	)
 }
 
@@ -254,6 +256,7 @@ func TestRunCheck(t *testing.T) {
 	c.Result.DetermineResultStatus(false)
 	assert.Equal(result.Pass, c.Result.Status)
 	assert.EqualValues([]string{"no error found"}, c.Result.Passes)
+	assert.EqualValues(map[string]string{"errors": "0", "file errors": "0"}, c.Result.Facts)
 
 	// PHP errors detected.
 	c = PhpStanCheck{
@@ -272,6 +275,10 @@ func TestRunCheck(t *testing.T) {
 			BreachType: "key-values",
 			Key:        "file: /app/web/themes/custom/custom/test-theme/info.php",
 			Values:     []string{"line 3: Calling curl_exec() is forbidden, please change the code"},
+			Location: &result.Location{
+				File: "/app/web/themes/custom/custom/test-theme/info.php",
+				Line: 3,
+			},
 		}},
 		c.Result.Breaches,
 	)
@@ -4,8 +4,11 @@ import "github.com/salsadigitalauorg/shipshape/pkg/config"
 
 //go:generate go run ../../../cmd/gen.go registry --checkpackage=sca
 
+// RegisterChecks registers the application type check.
 func RegisterChecks() {
-	config.ChecksRegistry[AppType] = func() config.Check { return &AppTypeCheck{} }
+	config.MustRegister(AppType, func() config.Check { return &AppTypeCheck{} }, config.Meta{
+		Description: "Detects disallowed application types in the project",
+	})
 }
 
 func init() {
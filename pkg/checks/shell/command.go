@@ -0,0 +1,289 @@
+// Package shell provides a check running an arbitrary command and asserting
+// its exit code and outputs.
+package shell
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	shipjson "github.com/salsadigitalauorg/shipshape/pkg/checks/json"
+	"github.com/salsadigitalauorg/shipshape/pkg/checks/yaml"
+	"github.com/salsadigitalauorg/shipshape/pkg/command"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+//go:generate go run ../../../cmd/gen.go registry --checkpackage=shell
+
+const Command config.CheckType = "command"
+
+// Formats in which the stdout can be parsed.
+const (
+	FormatJson = "json"
+	FormatYaml = "yaml"
+)
+
+// CommandCheck runs a command through the shell and asserts its exit code,
+// its outputs against patterns, or the values of its parsed stdout.
+type CommandCheck struct {
+	config.CheckBase `yaml:",inline"`
+	// The command line, run with sh -c.
+	Command string `yaml:"command"`
+	// The working directory, relative to the project directory.
+	Dir     string            `yaml:"dir"`
+	Env     map[string]string `yaml:"env"`
+	Timeout time.Duration     `yaml:"timeout"`
+	// The expected exit code; defaults to 0.
+	ExitCode *int `yaml:"exit-code"`
+	// Patterns the outputs must, or must not, match.
+	StdoutMatch    string `yaml:"stdout-match"`
+	StdoutNotMatch string `yaml:"stdout-not-match"`
+	StderrMatch    string `yaml:"stderr-match"`
+	StderrNotMatch string `yaml:"stderr-not-match"`
+	// The format in which to parse the stdout: json or yaml. The json output
+	// is asserted with key-values, as in the json check, and the yaml output
+	// with values, as in the yaml check.
+	OutputFormat string              `yaml:"output-format"`
+	Values       []yaml.KeyValue     `yaml:"values"`
+	KeyValues    []shipjson.KeyValue `yaml:"key-values"`
+	// If set, the trimmed stdout is recorded as a fact of this name, e.g.
+	// for the PHP version.
+	Fact string `yaml:"fact"`
+
+	res command.Result
+}
+
+// RegisterChecks registers the shell command check.
+func RegisterChecks() {
+	config.MustRegister(Command, func() config.Check { return &CommandCheck{} }, config.Meta{
+		Description: "Runs a command and checks its exit code and outputs",
+	})
+}
+
+func init() {
+	RegisterChecks()
+}
+
+// Merge implementation for the command check.
+func (c *CommandCheck) Merge(mergeCheck config.Check) error {
+	commandMergeCheck := mergeCheck.(*CommandCheck)
+	if err := c.CheckBase.Merge(&commandMergeCheck.CheckBase); err != nil {
+		return err
+	}
+
+	utils.MergeString(&c.Command, commandMergeCheck.Command)
+	utils.MergeString(&c.Dir, commandMergeCheck.Dir)
+	for k, v := range commandMergeCheck.Env {
+		if c.Env == nil {
+			c.Env = map[string]string{}
+		}
+		c.Env[k] = v
+	}
+	if commandMergeCheck.Timeout != 0 {
+		c.Timeout = commandMergeCheck.Timeout
+	}
+	if commandMergeCheck.ExitCode != nil {
+		c.ExitCode = commandMergeCheck.ExitCode
+	}
+	utils.MergeString(&c.StdoutMatch, commandMergeCheck.StdoutMatch)
+	utils.MergeString(&c.StdoutNotMatch, commandMergeCheck.StdoutNotMatch)
+	utils.MergeString(&c.StderrMatch, commandMergeCheck.StderrMatch)
+	utils.MergeString(&c.StderrNotMatch, commandMergeCheck.StderrNotMatch)
+	utils.MergeString(&c.OutputFormat, commandMergeCheck.OutputFormat)
+	utils.MergeString(&c.Fact, commandMergeCheck.Fact)
+	yaml.MergeKeyValueSlice(&c.Values, commandMergeCheck.Values)
+	if len(commandMergeCheck.KeyValues) > 0 {
+		c.KeyValues = commandMergeCheck.KeyValues
+	}
+	return nil
+}
+
+// FetchData runs the command.
+func (c *CommandCheck) FetchData() {
+	if c.Command == "" {
+		c.AddError("command required")
+		return
+	}
+	if c.OutputFormat != "" && c.OutputFormat != FormatJson && c.OutputFormat != FormatYaml {
+		c.AddError("invalid output format: " + c.OutputFormat)
+		return
+	}
+
+	dir := c.GetProjectDir()
+	if c.Dir != "" {
+		dir = c.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(c.GetProjectDir(), dir)
+		}
+	}
+	env := []string{}
+	for k, v := range c.Env {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+
+	var err error
+	c.res, err = command.Run(command.ShellCommander("sh", "-c", c.Command),
+		command.Options{Dir: dir, Env: env, Timeout: c.Timeout})
+	if err != nil {
+		c.AddError("command failed to run: " + command.GetMsgFromCommandError(err))
+		return
+	}
+	c.DataMap = map[string][]byte{"stdout": c.res.Stdout}
+}
+
+// HasData is overridden so that a command which failed to run or an invalid
+// configuration is only reported once.
+func (c *CommandCheck) HasData(failCheck bool) bool {
+	if c.DataMap == nil && len(c.Result.Errors) > 0 {
+		return false
+	}
+	return c.CheckBase.HasData(failCheck)
+}
+
+// RunCheck asserts the exit code and outputs of the command.
+func (c *CommandCheck) RunCheck() {
+	expectedCode := 0
+	if c.ExitCode != nil {
+		expectedCode = *c.ExitCode
+	}
+	if c.res.ExitCode != expectedCode {
+		c.AddBreach(&result.ValueBreach{
+			ValueLabel:    "exit code",
+			Value:         fmt.Sprint(c.res.ExitCode),
+			ExpectedValue: fmt.Sprint(expectedCode),
+		})
+	} else {
+		c.AddPass(fmt.Sprintf("exit code is %d", expectedCode))
+	}
+
+	if c.Fact != "" {
+		c.AddFact(c.Fact, strings.TrimSpace(string(c.res.Stdout)))
+	}
+
+	c.checkPattern("stdout", c.res.Stdout, c.StdoutMatch, true)
+	c.checkPattern("stdout", c.res.Stdout, c.StdoutNotMatch, false)
+	c.checkPattern("stderr", c.res.Stderr, c.StderrMatch, true)
+	c.checkPattern("stderr", c.res.Stderr, c.StderrNotMatch, false)
+
+	switch c.OutputFormat {
+	case FormatJson:
+		c.checkJson()
+	case FormatYaml:
+		c.checkYaml()
+	}
+
+	if len(c.Result.Breaches) == 0 && len(c.Result.Errors) == 0 {
+		c.Result.Status = result.Pass
+	}
+}
+
+func (c *CommandCheck) checkPattern(output string, data []byte, pattern string, match bool) {
+	if pattern == "" {
+		return
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		c.AddError("invalid pattern: " + err.Error())
+		return
+	}
+
+	matched := re.Match(data)
+	switch {
+	case match && !matched:
+		c.AddBreach(&result.KeyValueBreach{
+			KeyLabel:   output,
+			Key:        "does not match",
+			ValueLabel: "pattern",
+			Value:      pattern,
+		})
+	case !match && matched:
+		c.AddBreach(&result.KeyValueBreach{
+			KeyLabel:   output,
+			Key:        "matches",
+			ValueLabel: "disallowed pattern",
+			Value:      pattern,
+		})
+	case match:
+		c.AddPass(fmt.Sprintf("%s matches '%s'", output, pattern))
+	default:
+		c.AddPass(fmt.Sprintf("%s does not match '%s'", output, pattern))
+	}
+}
+
+func (c *CommandCheck) checkJson() {
+	var data any
+	if err := json.Unmarshal(c.res.Stdout, &data); err != nil {
+		c.AddError("JSON error: " + err.Error())
+		return
+	}
+	for _, kv := range c.KeyValues {
+		kv, err := shipjson.ResolveValueFrom(data, kv, c.GetProjectDir())
+		if err != nil {
+			c.AddError(err.Error())
+			continue
+		}
+		kvr, fails, err := shipjson.CheckKeyValue(data, kv)
+		c.addKeyValueResult(kv.KeyValue, kvr, fails, err)
+	}
+}
+
+func (c *CommandCheck) checkYaml() {
+	n := yamlv3.Node{}
+	if err := yamlv3.Unmarshal(c.res.Stdout, &n); err != nil {
+		c.AddError("YAML error: " + err.Error())
+		return
+	}
+	for _, kv := range c.Values {
+		kv, err := yaml.ResolveValueFrom(n, kv, c.GetProjectDir())
+		if err != nil {
+			c.AddError(err.Error())
+			continue
+		}
+		kvr, fails, err := yaml.CheckKeyValue(n, kv)
+		c.addKeyValueResult(kv, kvr, fails, err)
+	}
+}
+
+func (c *CommandCheck) addKeyValueResult(kv yaml.KeyValue, kvr yaml.KeyValueResult, fails []string, err error) {
+	switch kvr {
+	case yaml.KeyValueError:
+		c.AddError(err.Error())
+	case yaml.KeyValueNotFound:
+		c.AddBreach(&result.KeyValueBreach{
+			KeyLabel:   "output",
+			Key:        "stdout",
+			ValueLabel: "key not found",
+			Value:      kv.Key,
+		})
+	case yaml.KeyValueNotEqual:
+		c.AddBreach(&result.KeyValueBreach{
+			KeyLabel:      "stdout",
+			Key:           kv.Key,
+			ValueLabel:    "actual",
+			ExpectedValue: kv.Expected(),
+			Value:         kv.Actual(fails),
+		})
+	case yaml.KeyValueDisallowedFound:
+		c.AddBreach(&result.KeyValuesBreach{
+			KeyLabel:   "stdout",
+			Key:        kv.Key,
+			ValueLabel: fmt.Sprintf("disallowed %s", kv.Key),
+			Values:     fails,
+		})
+	case yaml.KeyValueEqual:
+		if kv.IsList && !kv.HasOperator() {
+			c.AddPass(fmt.Sprintf("[stdout] no disallowed '%s'", kv.Key))
+		} else {
+			c.AddPass(fmt.Sprintf("[stdout] '%s' %s", kv.Key, kv.Condition()))
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package shell_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/checks/json"
+	. "github.com/salsadigitalauorg/shipshape/pkg/checks/shell"
+	"github.com/salsadigitalauorg/shipshape/pkg/checks/yaml"
+	"github.com/salsadigitalauorg/shipshape/pkg/command"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/internal"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/stretchr/testify/assert"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func TestRegisterChecks(t *testing.T) {
+	checksMap := map[config.CheckType]string{
+		Command: "*shell.CommandCheck",
+	}
+	for ct, ts := range checksMap {
+		factory, ok := config.ChecksRegistry.Get(ct)
+		assert.True(t, ok)
+		c := factory()
+		ctype := reflect.TypeOf(c).String()
+		if ctype != ts {
+			t.Errorf("expecting check of type '%s', got '%s'", ts, ctype)
+		}
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	c := CommandCheck{}
+	err := yamlv3.Unmarshal([]byte(`
+name: composer
+command: composer validate
+timeout: 30s
+exit-code: 1
+env:
+  COMPOSER: composer.json
+`), &c)
+	assert.NoError(err)
+	assert.Equal("composer validate", c.Command)
+	assert.Equal(30*time.Second, c.Timeout)
+	assert.Equal(1, *c.ExitCode)
+	assert.Equal(map[string]string{"COMPOSER": "composer.json"}, c.Env)
+}
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	one := 1
+	c := CommandCheck{
+		CheckBase: config.CheckBase{Name: "cmd1"},
+		Command:   "true",
+		Env:       map[string]string{"FOO": "foo"},
+	}
+	err := c.Merge(&CommandCheck{
+		Env:         map[string]string{"BAR": "bar"},
+		ExitCode:    &one,
+		StdoutMatch: "^ok",
+		Timeout:     time.Second,
+	})
+	assert.NoError(err)
+	assert.EqualValues(CommandCheck{
+		CheckBase:   config.CheckBase{Name: "cmd1"},
+		Command:     "true",
+		Env:         map[string]string{"FOO": "foo", "BAR": "bar"},
+		ExitCode:    &one,
+		StdoutMatch: "^ok",
+		Timeout:     time.Second,
+	}, c)
+
+	err = c.Merge(&CommandCheck{CheckBase: config.CheckBase{Name: "cmd2"}})
+	assert.Error(err, "can only merge checks with the same name")
+}
+
+func runCheck(c *CommandCheck) *result.Result {
+	c.Init(Command)
+	c.FetchData()
+	if c.HasData(true) && len(c.Result.Breaches) == 0 && len(c.Result.Errors) == 0 {
+		c.UnmarshalDataMap()
+		c.RunCheck()
+	}
+	c.Result.DetermineResultStatus(false)
+	return &c.Result
+}
+
+func TestCommandCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("noCommand", func(t *testing.T) {
+		r := runCheck(&CommandCheck{})
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.Equal([]string{"command required"}, r.Errors)
+	})
+
+	t.Run("invalidOutputFormat", func(t *testing.T) {
+		r := runCheck(&CommandCheck{Command: "true", OutputFormat: "xml"})
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.Equal([]string{"invalid output format: xml"}, r.Errors)
+	})
+
+	t.Run("exitCode", func(t *testing.T) {
+		r := runCheck(&CommandCheck{Command: "exit 2"})
+		assert.Equal(result.Fail, r.Status)
+		assert.EqualValues([]result.Breach{&result.ValueBreach{
+			BreachType:    result.BreachTypeValue,
+			CheckType:     "command",
+			Severity:      "normal",
+			ValueLabel:    "exit code",
+			Value:         "2",
+			ExpectedValue: "0",
+		}}, r.Breaches)
+
+		two := 2
+		r = runCheck(&CommandCheck{Command: "exit 2", ExitCode: &two})
+		assert.Equal(result.Pass, r.Status)
+		assert.Equal([]string{"exit code is 2"}, r.Passes)
+	})
+
+	t.Run("dirAndEnv", func(t *testing.T) {
+		dir := t.TempDir()
+		c := &CommandCheck{
+			Command:     "basename $(pwd); echo $FOO",
+			Dir:         "sub",
+			Env:         map[string]string{"FOO": "bar"},
+			StdoutMatch: "^sub\nbar\n$",
+		}
+		c.SetProjectDir(dir)
+		assert.NoError(os.Mkdir(dir+"/sub", 0755))
+		r := runCheck(c)
+		assert.Equal(result.Pass, r.Status)
+		assert.Empty(r.Breaches)
+	})
+
+	t.Run("patterns", func(t *testing.T) {
+		r := runCheck(&CommandCheck{
+			Command:        "echo 'all good'; echo 'deprecated' >&2",
+			StdoutMatch:    "good",
+			StdoutNotMatch: "error",
+			StderrNotMatch: "deprecated",
+		})
+		assert.Equal(result.Fail, r.Status)
+		assert.ElementsMatch([]string{
+			"exit code is 0",
+			"stdout matches 'good'",
+			"stdout does not match 'error'",
+		}, r.Passes)
+		assert.EqualValues([]result.Breach{&result.KeyValueBreach{
+			BreachType: result.BreachTypeKeyValue,
+			CheckType:  "command",
+			Severity:   "normal",
+			KeyLabel:   "stderr",
+			Key:        "matches",
+			ValueLabel: "disallowed pattern",
+			Value:      "deprecated",
+		}}, r.Breaches)
+	})
+
+	t.Run("fact", func(t *testing.T) {
+		r := runCheck(&CommandCheck{Command: "echo ' 8.1.2 '", Fact: "php version"})
+		assert.Equal(result.Pass, r.Status)
+		assert.EqualValues(map[string]string{"php version": "8.1.2"}, r.Facts)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r := runCheck(&CommandCheck{Command: "sleep 5", Timeout: 50 * time.Millisecond})
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.EqualValues([]string{"command failed to run: timed out after 50ms"}, r.Errors)
+	})
+}
+
+func TestCommandCheckOutputFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	curShellCommander := command.ShellCommander
+	defer func() { command.ShellCommander = curShellCommander }()
+
+	var generatedCommand string
+	t.Run("json", func(t *testing.T) {
+		stdout := `{"name": "foo/bar", "require": {"php": ">=8.1"}}`
+		command.ShellCommander = internal.ShellCommanderMaker(&stdout, nil, &generatedCommand)
+		r := runCheck(&CommandCheck{
+			Command:      "composer show --format=json",
+			OutputFormat: FormatJson,
+			KeyValues: []json.KeyValue{
+				{KeyValue: yaml.KeyValue{Key: "$.name", Value: "foo/bar"}},
+				{KeyValue: yaml.KeyValue{Key: "$.require.php", Value: ">=7.4"}},
+			},
+		})
+		assert.Equal("sh -c 'composer show --format=json'", generatedCommand)
+		assert.Equal(result.Fail, r.Status)
+		assert.Contains(r.Passes, "[stdout] '$.name' equals 'foo/bar'")
+		assert.EqualValues([]result.Breach{&result.KeyValueBreach{
+			BreachType:    result.BreachTypeKeyValue,
+			CheckType:     "command",
+			Severity:      "normal",
+			KeyLabel:      "stdout",
+			Key:           "$.require.php",
+			ValueLabel:    "actual",
+			ExpectedValue: ">=7.4",
+			Value:         ">=8.1",
+		}}, r.Breaches)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		stdout := "foo:\n  bar: baz\n"
+		command.ShellCommander = internal.ShellCommanderMaker(&stdout, nil, nil)
+		r := runCheck(&CommandCheck{
+			Command:      "drush config:get foo",
+			OutputFormat: FormatYaml,
+			Values: []yaml.KeyValue{
+				{Key: "foo.bar", Value: "baz"},
+				{Key: "foo.qux", Value: "baz"},
+			},
+		})
+		assert.Equal(result.Fail, r.Status)
+		assert.Contains(r.Passes, "[stdout] 'foo.bar' equals 'baz'")
+		assert.EqualValues([]result.Breach{&result.KeyValueBreach{
+			BreachType: result.BreachTypeKeyValue,
+			CheckType:  "command",
+			Severity:   "normal",
+			KeyLabel:   "output",
+			Key:        "stdout",
+			ValueLabel: "key not found",
+			Value:      "foo.qux",
+		}}, r.Breaches)
+	})
+
+	t.Run("invalidJson", func(t *testing.T) {
+		stdout := "not json"
+		command.ShellCommander = internal.ShellCommanderMaker(&stdout, nil, nil)
+		r := runCheck(&CommandCheck{Command: "foo", OutputFormat: FormatJson})
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.Len(r.Errors, 1)
+		assert.Contains(r.Errors[0], "JSON error: ")
+	})
+
+	t.Run("commandError", func(t *testing.T) {
+		command.ShellCommander = internal.ShellCommanderMaker(nil, errors.New("sh: not found"), nil)
+		r := runCheck(&CommandCheck{Command: "foo"})
+		assert.Equal(result.Fail, r.Status)
+		assert.Empty(r.Breaches)
+		assert.EqualValues([]string{"command failed to run: sh: not found"}, r.Errors)
+	})
+}
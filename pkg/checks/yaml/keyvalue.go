@@ -1,6 +1,9 @@
 package yaml
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/utils"
@@ -10,6 +13,8 @@ import (
 // It can be a simple Key=Value check, or match against a list of Disallowed or
 // Allowed values. If the source is a list then IsList must be true.
 // If Optional is set then the validation will not fail if the key is not present.
+// The expected Value can be looked up elsewhere using ValueFrom, and compared
+// using an Operator other than equality.
 type KeyValue struct {
 	Key        string   `yaml:"key"`
 	Value      string   `yaml:"value"`
@@ -18,6 +23,72 @@ type KeyValue struct {
 	Optional   bool     `yaml:"optional"`
 	Disallowed []string `yaml:"disallowed"`
 	Allowed    []string `yaml:"allowed"`
+	// If set, Value is looked up from another key rather than provided.
+	ValueFrom *ValueFrom `yaml:"value-from"`
+	// How the value is compared; defaults to equality.
+	Operator Operator `yaml:"operator"`
+}
+
+// ValueFrom references the value a KeyValue is compared against: a key of
+// another file or, if File is empty, of the data being checked.
+type ValueFrom struct {
+	// Path to the file, relative to the project directory.
+	File string `yaml:"file"`
+	Key  string `yaml:"key"`
+}
+
+// ResolveValueFromWith sets the Value of the KeyValue from its ValueFrom, if
+// any. The file referenced is decoded using unmarshal, and lookup returns the
+// values found for the key in it or else in the node provided, along with
+// whether they are all scalars.
+func ResolveValueFromWith[N any](
+	node N, kv KeyValue, projectDir string,
+	unmarshal func([]byte, any) error,
+	lookup func(node N, key string) ([]string, bool, error),
+) (KeyValue, error) {
+	if kv.ValueFrom == nil {
+		return kv, nil
+	}
+	vf := kv.ValueFrom
+	if kv.Value != "" {
+		return kv, fmt.Errorf("value and value-from are mutually exclusive for '%s'", kv.Key)
+	}
+	if vf.Key == "" {
+		return kv, fmt.Errorf("value-from key required for '%s'", kv.Key)
+	}
+
+	source := "data"
+	if vf.File != "" {
+		source = vf.File
+		fpath := vf.File
+		if !filepath.IsAbs(fpath) {
+			fpath = filepath.Join(projectDir, fpath)
+		}
+		data, err := os.ReadFile(fpath)
+		if err != nil {
+			return kv, fmt.Errorf("value-from file '%s' could not be read: %w", vf.File, err)
+		}
+		var fileNode N
+		if err := unmarshal(data, &fileNode); err != nil {
+			return kv, fmt.Errorf("value-from file '%s' could not be parsed: %w", vf.File, err)
+		}
+		node = fileNode
+	}
+
+	found, scalar, err := lookup(node, vf.Key)
+	if err != nil {
+		return kv, err
+	}
+	switch {
+	case len(found) == 0:
+		return kv, fmt.Errorf("value-from key '%s' not found in %s", vf.Key, source)
+	case len(found) > 1:
+		return kv, fmt.Errorf("value-from key '%s' matches multiple values in %s", vf.Key, source)
+	case !scalar:
+		return kv, fmt.Errorf("value-from key '%s' is not a scalar in %s", vf.Key, source)
+	}
+	kv.Value = found[0]
+	return kv, nil
 }
 
 // KeyValueResult represents the different outcomes of the KeyValue check.
@@ -0,0 +1,175 @@
+package yaml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Operator defines how the values found for a KeyValue are compared with
+// its Value; the default is a case-insensitive equality.
+type Operator string
+
+const (
+	OpEquals    Operator = "equals"
+	OpNotEquals Operator = "not-equals"
+	OpGt        Operator = "gt"
+	OpGte       Operator = "gte"
+	OpLt        Operator = "lt"
+	OpLte       Operator = "lte"
+	// Value is a regular expression the values must match.
+	OpRegex Operator = "regex"
+	// Value is a version constraint the values must satisfy, e.g. >=8.1.
+	OpSemver Operator = "semver"
+	// Value is the expected number of entries of a list or map, optionally
+	// prefixed by a comparison, e.g. >=1.
+	OpLength Operator = "length"
+	// The list contains Value, or the string contains it as a substring.
+	OpContains Operator = "contains"
+	// The key must be present, whatever its value.
+	OpExists Operator = "exists"
+	// The key must not be present.
+	OpAbsent Operator = "absent"
+)
+
+var operators = []Operator{OpEquals, OpNotEquals, OpGt, OpGte, OpLt, OpLte,
+	OpRegex, OpSemver, OpLength, OpContains, OpExists, OpAbsent}
+
+// HasOperator returns whether the KeyValue uses an operator other than the
+// default equality.
+func (kv KeyValue) HasOperator() bool {
+	return kv.Operator != "" && kv.Operator != OpEquals
+}
+
+// ValidateOperator returns an error if the operator is not supported.
+func (kv KeyValue) ValidateOperator() error {
+	if kv.Operator == "" {
+		return nil
+	}
+	for _, op := range operators {
+		if kv.Operator == op {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown operator '%s' for '%s'", kv.Operator, kv.Key)
+}
+
+// Condition describes what is asserted by the KeyValue, e.g. for messages.
+func (kv KeyValue) Condition() string {
+	switch kv.Operator {
+	case "", OpEquals:
+		return fmt.Sprintf("equals '%s'", kv.Value)
+	case OpExists, OpAbsent:
+		return string(kv.Operator)
+	}
+	return fmt.Sprintf("%s '%s'", kv.Operator, kv.Value)
+}
+
+// Actual describes the values that failed the KeyValue for breaches: all of
+// them for an operator, only the first for the default equality.
+func (kv KeyValue) Actual(fails []string) string {
+	if len(fails) == 0 {
+		return ""
+	}
+	if !kv.HasOperator() {
+		return fails[0]
+	}
+	return strings.Join(fails, ", ")
+}
+
+// Expected describes the expected value of the KeyValue for breaches.
+func (kv KeyValue) Expected() string {
+	if !kv.HasOperator() {
+		return kv.Value
+	}
+	if kv.Operator == OpExists || kv.Operator == OpAbsent {
+		return string(kv.Operator)
+	}
+	return fmt.Sprintf("%s %s", kv.Operator, kv.Value)
+}
+
+// Compare applies the operator to a scalar value; list operators and the
+// key's presence are handled by the CheckKeyValue functions.
+func (kv KeyValue) Compare(value string) (bool, error) {
+	switch kv.Operator {
+	case "", OpEquals:
+		return kv.Equals(value), nil
+	case OpNotEquals:
+		return !kv.Equals(value), nil
+	case OpGt, OpGte, OpLt, OpLte:
+		return compareNumbers(kv.Key, value, kv.Operator, kv.Value)
+	case OpRegex:
+		re, err := regexp.Compile(kv.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex for '%s': %w", kv.Key, err)
+		}
+		return re.MatchString(value), nil
+	case OpSemver:
+		c, err := version.NewConstraint(kv.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid version constraint for '%s': %w", kv.Key, err)
+		}
+		v, err := version.NewVersion(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid version '%s' for '%s': %w", value, kv.Key, err)
+		}
+		return c.Check(v), nil
+	case OpContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(kv.Value)), nil
+	}
+	return false, fmt.Errorf("operator '%s' cannot be applied to the value of '%s'", kv.Operator, kv.Key)
+}
+
+// CompareLength checks the number of entries of a list or map against the
+// Value of a length KeyValue.
+func (kv KeyValue) CompareLength(length int) (bool, error) {
+	constraint := strings.TrimSpace(kv.Value)
+	op := "=="
+	for _, prefix := range []string{">=", "<=", "==", "!=", ">", "<", "="} {
+		if strings.HasPrefix(constraint, prefix) {
+			op = prefix
+			constraint = strings.TrimSpace(strings.TrimPrefix(constraint, prefix))
+			break
+		}
+	}
+	expected, err := strconv.Atoi(constraint)
+	if err != nil {
+		return false, fmt.Errorf("invalid length '%s' for '%s'", kv.Value, kv.Key)
+	}
+	switch op {
+	case ">=":
+		return length >= expected, nil
+	case "<=":
+		return length <= expected, nil
+	case "!=":
+		return length != expected, nil
+	case ">":
+		return length > expected, nil
+	case "<":
+		return length < expected, nil
+	}
+	return length == expected, nil
+}
+
+func compareNumbers(key string, value string, op Operator, expected string) (bool, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false, fmt.Errorf("value '%s' of '%s' is not a number", value, key)
+	}
+	e, err := strconv.ParseFloat(strings.TrimSpace(expected), 64)
+	if err != nil {
+		return false, fmt.Errorf("expected value '%s' of '%s' is not a number", expected, key)
+	}
+	switch op {
+	case OpGt:
+		return v > e, nil
+	case OpGte:
+		return v >= e, nil
+	case OpLt:
+		return v < e, nil
+	}
+	return v <= e, nil
+}
@@ -0,0 +1,165 @@
+package yaml_test
+
+import (
+	"testing"
+
+	yamlv3 "gopkg.in/yaml.v3"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/checks/yaml"
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		kv       KeyValue
+		value    string
+		expected bool
+	}{
+		{KeyValue{Value: "Foo"}, "foo", true},
+		{KeyValue{Operator: OpEquals, Value: "foo"}, "bar", false},
+		{KeyValue{Operator: OpNotEquals, Value: "foo"}, "bar", true},
+		{KeyValue{Operator: OpGt, Value: "300"}, "300", false},
+		{KeyValue{Operator: OpGte, Value: "300"}, "300", true},
+		{KeyValue{Operator: OpLt, Value: "1.5"}, "1.2", true},
+		{KeyValue{Operator: OpLte, Value: "1"}, "2", false},
+		{KeyValue{Operator: OpRegex, Value: "^[a-z]+_[0-9]+$"}, "db_1", true},
+		{KeyValue{Operator: OpRegex, Value: "^[a-z]+$"}, "db_1", false},
+		{KeyValue{Operator: OpSemver, Value: ">=8.1"}, "8.2.10", true},
+		{KeyValue{Operator: OpSemver, Value: ">=8.1, <9"}, "7.4", false},
+		{KeyValue{Operator: OpContains, Value: "example"}, "www.Example.com", true},
+	}
+	for _, tt := range tests {
+		ok, err := tt.kv.Compare(tt.value)
+		assert.NoError(err)
+		assert.Equal(tt.expected, ok, "%s %s %s", tt.value, tt.kv.Operator, tt.kv.Value)
+	}
+
+	_, err := KeyValue{Key: "k", Operator: OpGt, Value: "1"}.Compare("foo")
+	assert.EqualError(err, "value 'foo' of 'k' is not a number")
+	_, err = KeyValue{Key: "k", Operator: OpSemver, Value: ">=8.1"}.Compare("latest")
+	assert.ErrorContains(err, "invalid version 'latest' for 'k'")
+	_, err = KeyValue{Key: "k", Operator: OpRegex, Value: "("}.Compare("foo")
+	assert.ErrorContains(err, "invalid regex for 'k'")
+}
+
+func TestCompareLength(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[string]bool{
+		"2": true, "==2": true, "= 2": true, "!=2": false,
+		">=1": true, ">2": false, "<3": true, "<=1": false,
+	}
+	for constraint, expected := range tests {
+		ok, err := KeyValue{Operator: OpLength, Value: constraint}.CompareLength(2)
+		assert.NoError(err)
+		assert.Equal(expected, ok, constraint)
+	}
+
+	_, err := KeyValue{Key: "k", Operator: OpLength, Value: ">= one"}.CompareLength(2)
+	assert.EqualError(err, "invalid length '>= one' for 'k'")
+}
+
+func TestCheckKeyValueOperators(t *testing.T) {
+	assert := assert.New(t)
+
+	node := yamlv3.Node{}
+	err := yamlv3.Unmarshal([]byte(`
+cache:
+  page:
+    max_age: 180
+php_version: 8.2.1
+trusted_host_patterns:
+  - ^example\.com$
+  - ^www\.example\.com$
+modules:
+  node: 0
+`), &node)
+	assert.NoError(err)
+
+	tests := []struct {
+		kv            KeyValue
+		expected      KeyValueResult
+		expectedFails []string
+	}{
+		{KeyValue{Key: "cache.page.max_age", Operator: OpGte, Value: "300"}, KeyValueNotEqual, []string{"180"}},
+		{KeyValue{Key: "cache.page.max_age", Operator: OpGte, Value: "60"}, KeyValueEqual, nil},
+		{KeyValue{Key: "php_version", Operator: OpSemver, Value: ">=8.1"}, KeyValueEqual, nil},
+		{KeyValue{Key: "trusted_host_patterns", Operator: OpLength, Value: ">=1"}, KeyValueEqual, nil},
+		{KeyValue{Key: "trusted_host_patterns", Operator: OpLength, Value: "3"}, KeyValueNotEqual, []string{"2"}},
+		{KeyValue{Key: "modules", Operator: OpLength, Value: "1"}, KeyValueEqual, nil},
+		{KeyValue{Key: "trusted_host_patterns", Operator: OpContains, Value: `^example\.com$`}, KeyValueEqual, nil},
+		{KeyValue{Key: "trusted_host_patterns", Operator: OpContains, Value: "foo"}, KeyValueNotEqual,
+			[]string{`^example\.com$, ^www\.example\.com$`}},
+		{KeyValue{Key: "trusted_host_patterns", IsList: true, Operator: OpRegex, Value: `\$$`}, KeyValueEqual, nil},
+		{KeyValue{Key: "modules.node", Operator: OpExists}, KeyValueEqual, nil},
+		{KeyValue{Key: "modules.php", Operator: OpExists}, KeyValueNotFound, nil},
+		{KeyValue{Key: "modules.php", Operator: OpAbsent}, KeyValueEqual, nil},
+		{KeyValue{Key: "modules.node", Operator: OpAbsent}, KeyValueNotEqual, []string{"present"}},
+		{KeyValue{Key: "modules.php", Operator: OpNotEquals, Value: "0"}, KeyValueNotFound, nil},
+		{KeyValue{Key: "modules.php", Optional: true, Operator: OpNotEquals, Value: "0"}, KeyValueEqual, nil},
+	}
+	for _, tt := range tests {
+		kvr, fails, err := CheckKeyValue(node, tt.kv)
+		assert.NoError(err, tt.kv.Condition())
+		assert.Equal(tt.expected, kvr, "%s %s", tt.kv.Key, tt.kv.Condition())
+		assert.Equal(tt.expectedFails, fails, "%s %s", tt.kv.Key, tt.kv.Condition())
+	}
+
+	_, _, err = CheckKeyValue(node, KeyValue{Key: "php_version", Operator: "newer"})
+	assert.EqualError(err, "unknown operator 'newer' for 'php_version'")
+	_, _, err = CheckKeyValue(node, KeyValue{Key: "php_version", Operator: OpLength, Value: "1"})
+	assert.EqualError(err, "'php_version' is not a list or map")
+	_, _, err = CheckKeyValue(node, KeyValue{Key: "trusted_host_patterns", Operator: OpRegex, Value: "foo"})
+	assert.EqualError(err, "a list of values was found for 'trusted_host_patterns' but is-list is not set")
+}
+
+func TestCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("equals 'foo'", KeyValue{Value: "foo"}.Condition())
+	assert.Equal("gte '300'", KeyValue{Operator: OpGte, Value: "300"}.Condition())
+	assert.Equal("exists", KeyValue{Operator: OpExists}.Condition())
+
+	assert.Equal("foo", KeyValue{Value: "foo"}.Expected())
+	assert.Equal("semver >=8.1", KeyValue{Operator: OpSemver, Value: ">=8.1"}.Expected())
+	assert.Equal("absent", KeyValue{Operator: OpAbsent}.Expected())
+
+	// Only the first failing value is reported for equality, all of them for
+	// an operator.
+	assert.Equal("a", KeyValue{Value: "foo"}.Actual([]string{"a", "b"}))
+	assert.Equal("a, b", KeyValue{Operator: OpRegex, Value: "^c"}.Actual([]string{"a", "b"}))
+	assert.Equal("", KeyValue{Value: "foo"}.Actual(nil))
+}
+
+func TestYamlBaseOperators(t *testing.T) {
+	assert := assert.New(t)
+
+	c := YamlBase{
+		CheckBase: config.CheckBase{
+			DataMap: map[string][]byte{
+				"data": []byte("page:\n  max_age: 180\nhosts: [a]\n"),
+			},
+		},
+		Values: []KeyValue{
+			{Key: "page.max_age", Operator: OpGte, Value: "300"},
+			{Key: "hosts", Operator: OpLength, Value: ">=1"},
+		},
+	}
+	c.UnmarshalDataMap()
+	c.RunCheck()
+	c.Result.DetermineResultStatus(false)
+	assert.Equal(result.Fail, c.Result.Status)
+	assert.Equal([]string{"[data] 'hosts' length '>=1'"}, c.Result.Passes)
+	assert.ElementsMatch([]result.Breach{&result.KeyValueBreach{
+		BreachType:    result.BreachTypeKeyValue,
+		KeyLabel:      "config:data",
+		Key:           "page.max_age",
+		ValueLabel:    "actual",
+		ExpectedValue: "gte 300",
+		Value:         "180",
+	}}, c.Result.Breaches)
+}
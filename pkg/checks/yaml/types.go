@@ -11,6 +11,10 @@ type YamlBase struct {
 	Values           []KeyValue `yaml:"values"`
 	Node             yaml.Node
 	NodeMap          map[string]yaml.Node
+
+	// files maps the config names to the file they were read from, relative
+	// to the project directory, to locate the breaches.
+	files map[string]string
 }
 
 // YamlCheck represents a Yaml file-based check, which can be for a single file
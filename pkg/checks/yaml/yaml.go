@@ -3,6 +3,9 @@ package yaml
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
@@ -13,9 +16,14 @@ import (
 
 //go:generate go run ../../../cmd/gen.go registry --checkpackage=yaml
 
+// RegisterChecks registers the yaml and yamllint checks.
 func RegisterChecks() {
-	config.ChecksRegistry[Yaml] = func() config.Check { return &YamlCheck{} }
-	config.ChecksRegistry[YamlLint] = func() config.Check { return &YamlLintCheck{} }
+	config.MustRegister(Yaml, func() config.Check { return &YamlCheck{} }, config.Meta{
+		Description: "Checks yaml files for the presence or absence of required/disallowed values",
+	})
+	config.MustRegister(YamlLint, func() config.Check { return &YamlLintCheck{} }, config.Meta{
+		Description: "Checks yaml files are valid",
+	})
 }
 
 func init() {
@@ -52,35 +60,95 @@ func (c *YamlBase) UnmarshalDataMap() {
 		n := yaml.Node{}
 		err := yaml.Unmarshal([]byte(data), &n)
 		if err != nil {
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 			return
 		}
 		c.NodeMap[configName] = n
 	}
 }
 
+// setFile records the file from which the config was read.
+func (c *YamlBase) setFile(configName string, fname string) {
+	if c.files == nil {
+		c.files = map[string]string{}
+	}
+	if rel, err := filepath.Rel(c.GetProjectDir(), fname); err == nil {
+		fname = rel
+	}
+	c.files[configName] = fname
+}
+
+// location returns the location of the breaches for the config, if it was
+// read from a file, pointing at the node when one is provided.
+func (c *YamlBase) location(configName string, node *yaml.Node) *result.Location {
+	f, ok := c.files[configName]
+	if !ok {
+		return nil
+	}
+	loc := &result.Location{File: f}
+	if node != nil {
+		loc.Line = node.Line
+		loc.Column = node.Column
+	}
+	return loc
+}
+
+// FileLocation returns the location of the file from which the config was
+// read, if any.
+func (c *YamlBase) FileLocation(configName string) *result.Location {
+	return c.location(configName, nil)
+}
+
+// failingNode returns the node found for the key which holds one of the
+// failing values, or else the first node found for the key.
+func failingNode(node yaml.Node, key string, fails []string) *yaml.Node {
+	foundNodes, err := utils.LookupYamlPath(&node, key)
+	if err != nil || len(foundNodes) == 0 {
+		return nil
+	}
+	for _, item := range foundNodes {
+		if item.Kind == yaml.SequenceNode {
+			for _, v := range item.Content {
+				if utils.StringSliceContains(fails, v.Value) {
+					return v
+				}
+			}
+		} else if utils.StringSliceContains(fails, item.Value) {
+			return item
+		}
+	}
+	return foundNodes[0]
+}
+
 // determineBreaches runs the actual checks against the list of KeyValues provided in
 // the Check configuration and determines possible breaches.
 func (c *YamlBase) determineBreaches(configName string) {
 	for _, kv := range c.Values {
+		kv, err := ResolveValueFrom(c.NodeMap[configName], kv, c.GetProjectDir())
+		if err != nil {
+			c.AddError(err.Error())
+			continue
+		}
 		kvr, fails, err := CheckKeyValue(c.NodeMap[configName], kv)
 		switch kvr {
 		case KeyValueError:
-			c.AddBreach(&result.ValueBreach{Value: err.Error()})
+			c.AddError(err.Error())
 		case KeyValueNotFound:
 			c.AddBreach(&result.KeyValueBreach{
 				KeyLabel:   "config",
 				Key:        configName,
 				ValueLabel: "key not found",
 				Value:      kv.Key,
+				Location:   c.location(configName, nil),
 			})
 		case KeyValueNotEqual:
 			c.AddBreach(&result.KeyValueBreach{
 				KeyLabel:      "config:" + configName,
 				Key:           kv.Key,
 				ValueLabel:    "actual",
-				ExpectedValue: kv.Value,
-				Value:         fails[0],
+				ExpectedValue: kv.Expected(),
+				Value:         kv.Actual(fails),
+				Location:      c.location(configName, failingNode(c.NodeMap[configName], kv.Key, fails)),
 			})
 		case KeyValueDisallowedFound:
 			c.AddBreach(&result.KeyValuesBreach{
@@ -88,29 +156,70 @@ func (c *YamlBase) determineBreaches(configName string) {
 				Key:        configName,
 				ValueLabel: fmt.Sprintf("disallowed %s", kv.Key),
 				Values:     fails,
+				Location:   c.location(configName, failingNode(c.NodeMap[configName], kv.Key, fails)),
 			})
 		case KeyValueEqual:
-			if kv.IsList {
+			if kv.IsList && !kv.HasOperator() {
 				c.AddPass(fmt.Sprintf("[%s] no disallowed '%s'", configName, kv.Key))
 			} else {
-				c.AddPass(fmt.Sprintf("[%s] '%s' equals '%s'", configName, kv.Key, kv.Value))
+				c.AddPass(fmt.Sprintf("[%s] '%s' %s", configName, kv.Key, kv.Condition()))
 			}
 		}
 	}
 }
 
+// ResolveValueFrom sets the Value of the KeyValue from its ValueFrom, if any,
+// looking the key up in the file referenced or else in the node provided.
+func ResolveValueFrom(node yaml.Node, kv KeyValue, projectDir string) (KeyValue, error) {
+	return ResolveValueFromWith(node, kv, projectDir, yaml.Unmarshal,
+		func(node yaml.Node, key string) ([]string, bool, error) {
+			found, err := utils.LookupYamlPath(&node, key)
+			if err != nil {
+				return nil, false, err
+			}
+			values := []string{}
+			scalar := true
+			for _, n := range found {
+				values = append(values, n.Value)
+				scalar = scalar && n.Kind == yaml.ScalarNode
+			}
+			return values, scalar, nil
+		})
+}
+
 // CheckKeyValue lookups the Yaml data for a specific KeyValue and returns the
 // result, actual values and errors.
 func CheckKeyValue(node yaml.Node, kv KeyValue) (KeyValueResult, []string, error) {
+	if err := kv.ValidateOperator(); err != nil {
+		return KeyValueError, nil, err
+	}
+
 	foundNodes, err := utils.LookupYamlPath(&node, kv.Key)
 	if err != nil {
 		return KeyValueError, nil, err
 	}
 
+	switch kv.Operator {
+	case OpAbsent:
+		if len(foundNodes) > 0 {
+			return KeyValueNotEqual, []string{"present"}, nil
+		}
+		return KeyValueEqual, nil, nil
+	case OpExists:
+		if len(foundNodes) == 0 {
+			return KeyValueNotFound, nil, nil
+		}
+		return KeyValueEqual, nil, nil
+	}
+
 	if len(foundNodes) == 0 && !kv.Optional {
 		return KeyValueNotFound, nil, nil
 	}
 
+	if kv.HasOperator() && len(kv.Allowed) == 0 && len(kv.Disallowed) == 0 {
+		return checkOperator(foundNodes, kv)
+	}
+
 	// Throw an error if we are checking a list but no allow/disallow list provided.
 	if len(kv.Allowed) == 0 && len(kv.Disallowed) == 0 && kv.IsList {
 		return KeyValueError, nil, errors.New("list of allowed or disallowed values not provided")
@@ -150,3 +259,74 @@ func CheckKeyValue(node yaml.Node, kv KeyValue) (KeyValueResult, []string, error
 	}
 	return KeyValueEqual, nil, nil
 }
+
+// checkOperator applies the KeyValue's operator to the nodes found.
+func checkOperator(foundNodes []*yaml.Node, kv KeyValue) (KeyValueResult, []string, error) {
+	fails := []string{}
+	addFail := func(v string) {
+		if !utils.StringSliceContains(fails, v) {
+			fails = append(fails, v)
+		}
+	}
+	compare := func(v string) error {
+		ok, err := kv.Compare(v)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			addFail(v)
+		}
+		return nil
+	}
+
+	for _, item := range foundNodes {
+		switch {
+		case kv.Operator == OpLength:
+			var length int
+			switch item.Kind {
+			case yaml.SequenceNode:
+				length = len(item.Content)
+			case yaml.MappingNode:
+				length = len(item.Content) / 2
+			default:
+				return KeyValueError, nil, fmt.Errorf("'%s' is not a list or map", kv.Key)
+			}
+			ok, err := kv.CompareLength(length)
+			if err != nil {
+				return KeyValueError, nil, err
+			}
+			if !ok {
+				addFail(strconv.Itoa(length))
+			}
+		case kv.Operator == OpContains && item.Kind == yaml.SequenceNode:
+			values := []string{}
+			found := false
+			for _, v := range item.Content {
+				values = append(values, v.Value)
+				if strings.EqualFold(v.Value, kv.Value) {
+					found = true
+				}
+			}
+			if !found {
+				addFail(strings.Join(values, ", "))
+			}
+		case item.Kind == yaml.SequenceNode:
+			if !kv.IsList {
+				return KeyValueError, nil, fmt.Errorf("a list of values was found for '%s' but is-list is not set", kv.Key)
+			}
+			for _, v := range item.Content {
+				if err := compare(v.Value); err != nil {
+					return KeyValueError, nil, err
+				}
+			}
+		default:
+			if err := compare(item.Value); err != nil {
+				return KeyValueError, nil, err
+			}
+		}
+	}
+	if len(fails) > 0 {
+		return KeyValueNotEqual, fails, nil
+	}
+	return KeyValueEqual, nil, nil
+}
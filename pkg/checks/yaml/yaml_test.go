@@ -53,10 +53,9 @@ foo:
 	}
 	c.UnmarshalDataMap()
 	assert.EqualValues(0, len(c.Result.Passes))
-	assert.ElementsMatch([]result.Breach{&result.ValueBreach{
-		BreachType: result.BreachTypeValue,
-		Value:      "yaml: line 4: found character that cannot start any token"}},
-		c.Result.Breaches)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"yaml: line 4: found character that cannot start any token"},
+		c.Result.Errors)
 
 	// Valid data.
 	c = YamlBase{
@@ -93,10 +92,9 @@ foo:
 	c.Result.DetermineResultStatus(false)
 
 	assert.Equal(result.Fail, c.Result.Status)
-	assert.ElementsMatch([]result.Breach{&result.ValueBreach{
-		BreachType: result.BreachTypeValue,
-		Value:      "invalid character '&' at position 3, following \"baz\""}},
-		c.Result.Breaches)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"invalid character '&' at position 3, following \"baz\""},
+		c.Result.Errors)
 }
 
 func TestYamlCheckKeyValue(t *testing.T) {
@@ -455,3 +453,98 @@ foo:
 	assert.EqualValues(0, len(c.Result.Breaches))
 	assert.EqualValues([]string{"[data] no disallowed 'foo'"}, c.Result.Passes)
 }
+
+func TestResolveValueFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	node := yamlv3.Node{}
+	err := yamlv3.Unmarshal([]byte(`
+check:
+  interval_days: 7
+  expected_days: 7
+notification:
+  emails:
+    - admin@example.com
+`), &node)
+	assert.NoError(err)
+
+	// No reference.
+	kv, err := ResolveValueFrom(node, KeyValue{Key: "check.interval_days", Value: "7"}, "testdata")
+	assert.NoError(err)
+	assert.Equal("7", kv.Value)
+
+	// Same data.
+	kv, err = ResolveValueFrom(node, KeyValue{
+		Key:       "check.interval_days",
+		ValueFrom: &ValueFrom{Key: "check.expected_days"},
+	}, "testdata")
+	assert.NoError(err)
+	assert.Equal("7", kv.Value)
+
+	// Another file.
+	kv, err = ResolveValueFrom(node, KeyValue{
+		Key:       "check.interval_days",
+		ValueFrom: &ValueFrom{File: "foo.bar.yml", Key: "check.interval_days"},
+	}, "testdata")
+	assert.NoError(err)
+	assert.Equal("7", kv.Value)
+
+	tests := map[string]ValueFrom{
+		"value-from key required for 'check.interval_days'":                                                     {File: "foo.bar.yml"},
+		"value-from key 'check.foo' not found in data":                                                          {Key: "check.foo"},
+		"value-from key 'check.foo' not found in foo.bar.yml":                                                   {File: "foo.bar.yml", Key: "check.foo"},
+		"value-from key 'notification.emails' is not a scalar in data":                                          {Key: "notification.emails"},
+		"value-from file 'missing.yml' could not be read: open testdata/missing.yml: no such file or directory": {File: "missing.yml", Key: "foo"},
+	}
+	for expectedErr, vf := range tests {
+		vf := vf
+		_, err = ResolveValueFrom(node, KeyValue{Key: "check.interval_days", ValueFrom: &vf}, "testdata")
+		assert.EqualError(err, expectedErr)
+	}
+
+	_, err = ResolveValueFrom(node, KeyValue{
+		Key:       "check.interval_days",
+		Value:     "7",
+		ValueFrom: &ValueFrom{Key: "check.expected_days"},
+	}, "testdata")
+	assert.EqualError(err, "value and value-from are mutually exclusive for 'check.interval_days'")
+}
+
+func TestYamlBaseValueFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	c := YamlBase{
+		CheckBase: config.CheckBase{
+			DataMap: map[string][]byte{
+				"data": []byte("check:\n  interval_days: 3\n"),
+			},
+		},
+		Values: []KeyValue{
+			{
+				Key:       "check.interval_days",
+				ValueFrom: &ValueFrom{File: "update.settings.yml", Key: "check.interval_days"},
+			},
+			{
+				Key:       "check.interval_days",
+				ValueFrom: &ValueFrom{File: "update.settings.yml", Key: "check.foo"},
+			},
+		},
+	}
+	c.SetProjectDir("testdata")
+	c.UnmarshalDataMap()
+	c.RunCheck()
+	c.Result.DetermineResultStatus(false)
+	assert.Equal(result.Fail, c.Result.Status)
+	assert.ElementsMatch([]result.Breach{
+		&result.KeyValueBreach{
+			BreachType:    result.BreachTypeKeyValue,
+			KeyLabel:      "config:data",
+			Key:           "check.interval_days",
+			ValueLabel:    "actual",
+			ExpectedValue: "7",
+			Value:         "3",
+		},
+	}, c.Result.Breaches)
+	assert.Equal([]string{"value-from key 'check.foo' not found in update.settings.yml"},
+		c.Result.Errors)
+}
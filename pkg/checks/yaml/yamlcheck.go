@@ -31,6 +31,7 @@ func (c *YamlCheck) Merge(mergeCheck config.Check) error {
 // the provided file key.
 func (c *YamlCheck) readFile(fkey string, fname string) {
 	var err error
+	c.setFile(fkey, fname)
 	c.DataMap[fkey], err = os.ReadFile(fname)
 	if err != nil {
 		// No failure if missing file and ignoring missing.
@@ -38,9 +39,7 @@ func (c *YamlCheck) readFile(fkey string, fname string) {
 			c.AddPass(fmt.Sprintf("File %s does not exist", fname))
 			c.Result.Status = result.Pass
 		} else {
-			c.AddBreach(&result.ValueBreach{
-				ValueLabel: "error reading file: " + fname,
-				Value:      err.Error()})
+			c.AddError(fmt.Sprintf("error reading file: %s: %s", fname, err))
 		}
 	}
 }
@@ -51,13 +50,13 @@ func (c *YamlCheck) readFile(fkey string, fname string) {
 func (c *YamlCheck) FetchData() {
 	c.DataMap = map[string][]byte{}
 	if c.File != "" {
-		c.readFile(filepath.Join(c.Path, c.File), filepath.Join(config.ProjectDir, c.Path, c.File))
+		c.readFile(filepath.Join(c.Path, c.File), filepath.Join(c.GetProjectDir(), c.Path, c.File))
 	} else if len(c.Files) > 0 {
 		for _, f := range c.Files {
-			c.readFile(filepath.Join(c.Path, f), filepath.Join(config.ProjectDir, c.Path, f))
+			c.readFile(filepath.Join(c.Path, f), filepath.Join(c.GetProjectDir(), c.Path, f))
 		}
 	} else if c.Pattern != "" {
-		configPath := filepath.Join(config.ProjectDir, c.Path)
+		configPath := filepath.Join(c.GetProjectDir(), c.Path)
 		files, err := utils.FindFiles(configPath, c.Pattern, c.ExcludePattern, nil)
 		if err != nil {
 			// No failure if missing path and ignoring missing.
@@ -65,9 +64,7 @@ func (c *YamlCheck) FetchData() {
 				c.AddPass(fmt.Sprintf("Path %s does not exist", configPath))
 				c.Result.Status = result.Pass
 			} else {
-				c.AddBreach(&result.ValueBreach{
-					ValueLabel: "error finding files in path: " + configPath,
-					Value:      err.Error()})
+				c.AddError(fmt.Sprintf("error finding files in path: %s: %s", configPath, err))
 			}
 			return
 		}
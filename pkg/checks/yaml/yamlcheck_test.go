@@ -69,15 +69,7 @@ func TestYamlCheckFetchData(t *testing.T) {
 				},
 				File: "non-existent.yml",
 			},
-			ExpectBreaches: []result.Breach{
-				&result.ValueBreach{
-					BreachType: "value",
-					CheckType:  "yaml",
-					Severity:   "normal",
-					ValueLabel: "error reading file: testdata/non-existent.yml",
-					Value:      "open testdata/non-existent.yml: no such file or directory",
-				},
-			},
+			ExpectErrors: []string{"error reading file: testdata/non-existent.yml: open testdata/non-existent.yml: no such file or directory"},
 		},
 
 		{
@@ -126,15 +118,7 @@ notification:
 				Pattern: "*.bar.yml",
 				Path:    "",
 			},
-			ExpectBreaches: []result.Breach{
-				&result.ValueBreach{
-					BreachType: "value",
-					CheckType:  "yaml",
-					Severity:   "normal",
-					ValueLabel: "error finding files in path: testdata",
-					Value:      "error parsing regexp: missing argument to repetition operator: `*`",
-				},
-			},
+			ExpectErrors: []string{"error finding files in path: testdata: error parsing regexp: missing argument to repetition operator: `*`"},
 		},
 
 		{
@@ -238,3 +222,59 @@ notification:
 		})
 	}
 }
+
+func TestYamlCheckBreachLocation(t *testing.T) {
+	assert := assert.New(t)
+
+	config.ProjectDir = "testdata"
+	c := YamlCheck{
+		YamlBase: YamlBase{
+			Values: []KeyValue{{Key: "check.interval_days", Value: "5"}},
+		},
+		File: "update.settings.yml",
+	}
+	c.Init(Yaml)
+	c.FetchData()
+	c.UnmarshalDataMap()
+	c.RunCheck()
+	if assert.Len(c.Result.Breaches, 1) {
+		assert.Equal(&result.Location{File: "update.settings.yml", Line: 2, Column: 18},
+			result.BreachGetLocation(c.Result.Breaches[0]))
+	}
+
+	c = YamlCheck{
+		YamlBase: YamlBase{
+			Values: []KeyValue{{
+				Key:        "notification.emails",
+				IsList:     true,
+				Disallowed: []string{"admin@example.com"},
+			}},
+		},
+		File: "update.settings.yml",
+	}
+	c.Init(Yaml)
+	c.FetchData()
+	c.UnmarshalDataMap()
+	c.RunCheck()
+	if assert.Len(c.Result.Breaches, 1) {
+		assert.Equal(&result.Location{File: "update.settings.yml", Line: 5, Column: 7},
+			result.BreachGetLocation(c.Result.Breaches[0]))
+	}
+
+	c = YamlCheck{
+		YamlBase: YamlBase{
+			Values: []KeyValue{{Key: "check.interval_days", Value: "5"}},
+		},
+		Path:    "dir",
+		Pattern: "foo.bar.yml",
+	}
+	c.Init(Yaml)
+	c.FetchData()
+	c.UnmarshalDataMap()
+	c.RunCheck()
+	locations := []string{}
+	for _, b := range c.Result.Breaches {
+		locations = append(locations, result.BreachGetLocation(b).File)
+	}
+	assert.ElementsMatch([]string{"dir/foo.bar.yml", "dir/subdir/foo.bar.yml"}, locations)
+}
@@ -28,11 +28,13 @@ func (c *YamlLintCheck) UnmarshalDataMap() {
 			if typeErr, ok := err.(*yaml.TypeError); ok {
 				c.AddBreach(&result.ValueBreach{
 					ValueLabel: "cannot decode yaml: " + f,
-					Value:      strings.Join(typeErr.Errors, "\n")})
+					Value:      strings.Join(typeErr.Errors, "\n"),
+					Location:   c.location(f, nil)})
 			} else {
 				c.AddBreach(&result.ValueBreach{
 					ValueLabel: "yaml error: " + f,
-					Value:      err.Error()})
+					Value:      err.Error(),
+					Location:   c.location(f, nil)})
 			}
 		} else {
 			c.AddPass(fmt.Sprintf("%s has valid yaml.", f))
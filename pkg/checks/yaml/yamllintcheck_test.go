@@ -88,37 +88,15 @@ func TestYamlLintCheckFetchData(t *testing.T) {
 	c.Init(YamlLint)
 	c.FetchData()
 	assert.Empty(c.Result.Passes)
-	assert.ElementsMatch(
-		[]result.Breach{
-			&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "yamllint",
-				CheckName:  "Test yaml lint",
-				Severity:   "normal",
-				ValueLabel: "error reading file: testdata/non-existent-file.yml",
-				Value:      "open testdata/non-existent-file.yml: no such file or directory",
-			},
-		},
-		c.Result.Breaches,
-	)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"error reading file: testdata/non-existent-file.yml: open testdata/non-existent-file.yml: no such file or directory"}, c.Result.Errors)
 
 	c = MockYamlLintCheck("", []string{"non-existent-file.yml", "yamllint-invalid.yml"}, false)
 	c.Init(YamlLint)
 	c.FetchData()
 	assert.Empty(c.Result.Passes)
-	assert.ElementsMatch(
-		[]result.Breach{
-			&result.ValueBreach{
-				BreachType: "value",
-				CheckType:  "yamllint",
-				CheckName:  "Test yaml lint",
-				Severity:   "normal",
-				ValueLabel: "error reading file: testdata/non-existent-file.yml",
-				Value:      "open testdata/non-existent-file.yml: no such file or directory",
-			},
-		},
-		c.Result.Breaches,
-	)
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"error reading file: testdata/non-existent-file.yml: open testdata/non-existent-file.yml: no such file or directory"}, c.Result.Errors)
 }
 
 func TestYamlLintCheckUnmarshalDataMap(t *testing.T) {
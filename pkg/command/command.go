@@ -7,9 +7,13 @@
 package command
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io/fs"
+	"os"
 	"os/exec"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -54,3 +58,78 @@ func GetMsgFromCommandError(err error) string {
 	}
 	return errMsg
 }
+
+// Options customise how Run executes a command.
+type Options struct {
+	Dir string
+	// Added to the environment of the current process.
+	Env     []string
+	Timeout time.Duration
+}
+
+// Result holds the outputs and exit code of a command executed by Run.
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// Run executes the command with the options provided, which are only applied
+// to an ExecShellCommand, not to mocks. A non-zero exit code is not an error,
+// but failing to start the command or exceeding the timeout is.
+func Run(cmd IShellCommand, opts Options) (Result, error) {
+	res := Result{}
+	c, ok := cmd.(*ExecShellCommand)
+	if !ok {
+		out, err := cmd.Output()
+		res.Stdout = out
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			res.Stderr = exitErr.Stderr
+			res.ExitCode = exitErr.ExitCode()
+			return res, nil
+		}
+		return res, err
+	}
+
+	c.Dir = opts.Dir
+	if len(opts.Env) > 0 {
+		c.Env = append(os.Environ(), opts.Env...)
+	}
+	stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+	c.Stdout, c.Stderr = &stdout, &stderr
+	// Don't wait indefinitely for child processes holding the outputs once
+	// the command has been killed.
+	c.WaitDelay = time.Second
+
+	log.WithField("command", c).Debug("running command")
+	if err := c.Start(); err != nil {
+		return res, err
+	}
+	done := make(chan error, 1)
+	go func() { done <- c.Wait() }()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+	select {
+	case err = <-done:
+	case <-timeout:
+		c.Process.Kill()
+		<-done
+		return res, fmt.Errorf("timed out after %s", opts.Timeout)
+	}
+
+	res.Stdout, res.Stderr = stdout.Bytes(), stderr.Bytes()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		res.ExitCode = exitErr.ExitCode()
+		return res, nil
+	}
+	return res, err
+}
@@ -5,6 +5,7 @@ import (
 	"io/fs"
 	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/command"
 	"github.com/salsadigitalauorg/shipshape/pkg/internal"
@@ -92,3 +93,52 @@ func TestGetMsgFromCommandError(t *testing.T) {
 		assert.Equal("basic error", msg)
 	})
 }
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("outputs", func(t *testing.T) {
+		res, err := command.Run(command.NewExecShellCommander(
+			"sh", "-c", "echo out; echo err >&2; exit 3"), command.Options{})
+		assert.NoError(err)
+		assert.Equal("out\n", string(res.Stdout))
+		assert.Equal("err\n", string(res.Stderr))
+		assert.Equal(3, res.ExitCode)
+	})
+
+	t.Run("dirAndEnv", func(t *testing.T) {
+		dir := t.TempDir()
+		res, err := command.Run(command.NewExecShellCommander(
+			"sh", "-c", "pwd; echo $FOO"), command.Options{
+			Dir: dir,
+			Env: []string{"FOO=bar"},
+		})
+		assert.NoError(err)
+		assert.Equal(dir+"\nbar\n", string(res.Stdout))
+		assert.Equal(0, res.ExitCode)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, err := command.Run(command.NewExecShellCommander("sleep", "5"),
+			command.Options{Timeout: 50 * time.Millisecond})
+		assert.EqualError(err, "timed out after 50ms")
+	})
+
+	t.Run("notFound", func(t *testing.T) {
+		_, err := command.Run(command.NewExecShellCommander("/nonexistent/bin"),
+			command.Options{})
+		assert.Error(err)
+	})
+
+	t.Run("mock", func(t *testing.T) {
+		res, err := command.Run(internal.TestShellCommand{
+			OutputterFunc: func() ([]byte, error) {
+				return []byte("foo"), &exec.ExitError{Stderr: []byte("bar")}
+			},
+		}, command.Options{Dir: "/ignored"})
+		assert.NoError(err)
+		assert.Equal("foo", string(res.Stdout))
+		assert.Equal("bar", string(res.Stderr))
+		assert.Equal(-1, res.ExitCode)
+	})
+}
@@ -13,7 +13,15 @@ func (c *CheckBase) Init(ct CheckType) {
 		c.Severity = NormalSeverity
 	}
 	if c.Result.CheckType == "" {
-		c.Result = result.Result{Name: c.Name, CheckType: string(ct)}
+		c.Result = result.Result{
+			Name:                c.Name,
+			CheckType:           string(ct),
+			Description:         c.Description,
+			Rationale:           c.Rationale,
+			References:          c.References,
+			RemediationGuidance: c.RemediationGuidance,
+			Controls:            c.Controls,
+		}
 	}
 	if c.Result.Severity == "" {
 		c.Result.Severity = string(c.Severity)
@@ -33,6 +41,30 @@ func (c *CheckBase) GetType() CheckType { return c.cType }
 // GetSeverity returns the severity of a check.
 func (c *CheckBase) GetSeverity() Severity { return c.Severity }
 
+// SetSeverity changes the severity of a check, including its result's if
+// already initialised.
+func (c *CheckBase) SetSeverity(s Severity) {
+	c.Severity = s
+	if c.Result.Severity != "" {
+		c.Result.Severity = string(s)
+	}
+}
+
+// GetTags returns the tags of a check.
+func (c *CheckBase) GetTags() []string { return c.Tags }
+
+// SetProjectDir sets the directory of the project the check runs against.
+func (c *CheckBase) SetProjectDir(dir string) { c.projectDir = dir }
+
+// GetProjectDir returns the directory of the project the check runs against,
+// defaulting to the global ProjectDir.
+func (c *CheckBase) GetProjectDir() string {
+	if c.projectDir != "" {
+		return c.projectDir
+	}
+	return ProjectDir
+}
+
 // Merge merges values from another check into this one.
 func (c *CheckBase) Merge(mergeCheck Check) error {
 	// Empty name means the merge will be done for all checks of the same type.
@@ -42,9 +74,34 @@ func (c *CheckBase) Merge(mergeCheck Check) error {
 	if mergeCheck.GetSeverity() != "" {
 		c.Severity = mergeCheck.GetSeverity()
 	}
+	if len(mergeCheck.GetTags()) > 0 {
+		c.Tags = mergeCheck.GetTags()
+	}
+	// The metadata is held by the CheckBase embedded in the checks.
+	if mcb, ok := mergeCheck.(interface{ checkBase() *CheckBase }); ok {
+		mc := mcb.checkBase()
+		if mc.Description != "" {
+			c.Description = mc.Description
+		}
+		if mc.Rationale != "" {
+			c.Rationale = mc.Rationale
+		}
+		if len(mc.References) > 0 {
+			c.References = mc.References
+		}
+		if mc.RemediationGuidance != "" {
+			c.RemediationGuidance = mc.RemediationGuidance
+		}
+		if len(mc.Controls) > 0 {
+			c.Controls = mc.Controls
+		}
+	}
 	return nil
 }
 
+// checkBase gives access to the CheckBase embedded in a check.
+func (c *CheckBase) checkBase() *CheckBase { return c }
+
 // RequiresData indicates whether the check requires a DataMap to run against.
 // It is designed as opt-out, so remember to set it to false if you are creating
 // a check that does not require the DataMap.
@@ -62,7 +119,9 @@ func (c *CheckBase) FetchData() {}
 // The Check can optionally be marked as failed if the dataMap is not populated.
 func (c *CheckBase) HasData(failCheck bool) bool {
 	if c.DataMap == nil {
-		if failCheck {
+		// The errors encountered while fetching the data already explain
+		// why there is none.
+		if failCheck && len(c.Result.Errors) == 0 {
 			c.AddBreach(&result.ValueBreach{Value: "no data available"})
 		}
 		return false
@@ -91,11 +150,26 @@ func (c *CheckBase) AddPass(msg string) {
 	)
 }
 
+// AddFact records informational data about the project in the Result,
+// replacing any previous value of the same name.
+func (c *CheckBase) AddFact(name string, value string) {
+	if c.Result.Facts == nil {
+		c.Result.Facts = map[string]string{}
+	}
+	c.Result.Facts[name] = value
+}
+
 // AddWarning appends a Warning message to the result.
 func (c *CheckBase) AddWarning(msg string) {
 	c.Result.Warnings = append(c.Result.Warnings, msg)
 }
 
+// AddError appends an Error to the Result; errors are problems encountered
+// while executing the check, rather than policy breaches.
+func (c *CheckBase) AddError(msg string) {
+	c.Result.Errors = append(c.Result.Errors, msg)
+}
+
 // SetPerformRemediation sets the flag for whether to remediate or not.
 func (c *CheckBase) SetPerformRemediation(flag bool) {
 	c.PerformRemediation = flag
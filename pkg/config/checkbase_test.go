@@ -25,6 +25,19 @@ func TestCheckBaseInit(t *testing.T) {
 	assert.Equal("foo", c.Result.Name)
 	assert.Equal(string(NormalSeverity), c.Result.Severity)
 	assert.Equal(testCheckForCheckBaseInitType, c.GetType())
+
+	c = CheckBase{
+		Name:                "foo",
+		Description:         "desc",
+		Rationale:           "why",
+		References:          []string{"https://example.com"},
+		RemediationGuidance: "fix",
+	}
+	c.Init(testCheckForCheckBaseInitType)
+	assert.Equal("desc", c.Result.Description)
+	assert.Equal("why", c.Result.Rationale)
+	assert.Equal([]string{"https://example.com"}, c.Result.References)
+	assert.Equal("fix", c.Result.RemediationGuidance)
 }
 
 func TestCheckBaseMerge(t *testing.T) {
@@ -41,6 +54,30 @@ func TestCheckBaseMerge(t *testing.T) {
 	c = CheckBase{Severity: LowSeverity}
 	c.Merge(&CheckBase{Name: "foo"})
 	assert.Equal(LowSeverity, c.Severity)
+
+	c = CheckBase{Name: "foo", Tags: []string{"a"}}
+	c.Merge(&CheckBase{Name: "foo", Tags: []string{"b", "c"}})
+	assert.Equal([]string{"b", "c"}, c.Tags)
+
+	c = CheckBase{Name: "foo", Description: "desc", Rationale: "why"}
+	c.Merge(&CheckBase{Name: "foo", Rationale: "because", RemediationGuidance: "fix"})
+	assert.Equal("desc", c.Description)
+	assert.Equal("because", c.Rationale)
+	assert.Equal("fix", c.RemediationGuidance)
+}
+
+func TestCheckBaseSetSeverity(t *testing.T) {
+	assert := assert.New(t)
+
+	c := CheckBase{Name: "foo"}
+	c.SetSeverity(HighSeverity)
+	assert.Equal(HighSeverity, c.Severity)
+	assert.Equal("", c.Result.Severity)
+
+	c.Init(testCheckForCheckBaseInitType)
+	c.SetSeverity(LowSeverity)
+	assert.Equal(LowSeverity, c.Severity)
+	assert.Equal(string(LowSeverity), c.Result.Severity)
 }
 
 func TestRequiresData(t *testing.T) {
@@ -8,14 +8,25 @@ import (
 )
 
 var ProjectDir string
-var ChecksRegistry = map[CheckType]func() Check{}
 
 func (cm *CheckMap) UnmarshalYAML(value *yaml.Node) error {
+	newcm, err := DecodeChecks(value, ChecksRegistry)
+	if err != nil {
+		return err
+	}
+	*cm = newcm
+	return nil
+}
+
+// DecodeChecks creates the checks defined in the yaml node, using the
+// factories of the registry provided.
+func DecodeChecks(value *yaml.Node, registry *Registry) (CheckMap, error) {
 	newcm := make(CheckMap)
-	for ct, cFunc := range ChecksRegistry {
+	for _, ct := range registry.Types() {
+		cFunc, _ := registry.Get(ct)
 		check_values, err := utils.LookupYamlPath(value, string(ct))
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if len(check_values) == 0 {
@@ -23,7 +34,7 @@ func (cm *CheckMap) UnmarshalYAML(value *yaml.Node) error {
 		}
 
 		if check_values[0].Kind != yaml.SequenceNode {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"list required under check type '%s', got %s instead",
 				ct, check_values[0].ShortTag())
 		}
@@ -32,12 +43,68 @@ func (cm *CheckMap) UnmarshalYAML(value *yaml.Node) error {
 			c := cFunc()
 			err := cv.Decode(c)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			newcm[ct] = append(newcm[ct], c)
 		}
 	}
-	*cm = newcm
+	return newcm, nil
+}
+
+// ParseConfig parses the config data, creating the checks from the registry
+// provided instead of ChecksRegistry.
+func ParseConfig(data []byte, registry *Registry) (Config, error) {
+	cfg := Config{}
+	doc := yaml.Node{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return cfg, err
+	}
+	if len(doc.Content) == 0 {
+		return cfg, nil
+	}
+	root := doc.Content[0]
+
+	// The checks are decoded separately so that the registry is used.
+	var checksNode *yaml.Node
+	if root.Kind == yaml.MappingNode {
+		content := []*yaml.Node{}
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "checks" {
+				checksNode = root.Content[i+1]
+				continue
+			}
+			content = append(content, root.Content[i], root.Content[i+1])
+		}
+		root.Content = content
+	}
+	if err := root.Decode(&cfg); err != nil {
+		return cfg, err
+	}
+	if err := cfg.validateFailSeverities(); err != nil {
+		return cfg, err
+	}
+	if checksNode != nil {
+		checks, err := DecodeChecks(checksNode, registry)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Checks = checks
+	}
+	return cfg, nil
+}
+
+// validateFailSeverities ensures the fail severities of the config and its
+// fail policy are known ones, since they are compared to the breaches'.
+func (cfg *Config) validateFailSeverities() error {
+	if cfg.FailSeverity != "" && !IsValidSeverity(cfg.FailSeverity) {
+		return fmt.Errorf("invalid fail-severity '%s'", cfg.FailSeverity)
+	}
+	for ct, o := range cfg.FailPolicy.CheckTypes {
+		if o.FailSeverity != "" && !IsValidSeverity(o.FailSeverity) {
+			return fmt.Errorf("invalid fail-severity '%s' for '%s' in the fail policy",
+				o.FailSeverity, ct)
+		}
+	}
 	return nil
 }
 
@@ -46,9 +113,53 @@ func (cfg *Config) Merge(mrgCfg Config) error {
 	if mrgCfg.ProjectDir != "" {
 		cfg.ProjectDir = mrgCfg.ProjectDir
 	}
+	if len(mrgCfg.Projects) > 0 {
+		cfg.Projects = mrgCfg.Projects
+	}
+	if mrgCfg.PluginsDir != "" {
+		cfg.PluginsDir = mrgCfg.PluginsDir
+	}
+	for _, p := range mrgCfg.Plugins {
+		replaced := false
+		for i := range cfg.Plugins {
+			if cfg.Plugins[i].Type == p.Type {
+				cfg.Plugins[i] = p
+				replaced = true
+			}
+		}
+		if !replaced {
+			cfg.Plugins = append(cfg.Plugins, p)
+		}
+	}
 	if mrgCfg.FailSeverity != "" {
 		cfg.FailSeverity = mrgCfg.FailSeverity
 	}
+	for s, m := range mrgCfg.FailPolicy.MaxBreaches {
+		if cfg.FailPolicy.MaxBreaches == nil {
+			cfg.FailPolicy.MaxBreaches = map[Severity]int{}
+		}
+		cfg.FailPolicy.MaxBreaches[s] = m
+	}
+	for ct, o := range mrgCfg.FailPolicy.CheckTypes {
+		if cfg.FailPolicy.CheckTypes == nil {
+			cfg.FailPolicy.CheckTypes = map[CheckType]FailPolicyOverride{}
+		}
+		cfg.FailPolicy.CheckTypes[ct] = o
+	}
+	for env, overrides := range mrgCfg.SeverityOverrides {
+		if cfg.SeverityOverrides == nil {
+			cfg.SeverityOverrides = map[string]map[string]Severity{}
+		}
+		if cfg.SeverityOverrides[env] == nil {
+			cfg.SeverityOverrides[env] = map[string]Severity{}
+		}
+		for selector, s := range overrides {
+			cfg.SeverityOverrides[env][selector] = s
+		}
+	}
+	if len(mrgCfg.Outputs) > 0 {
+		cfg.Outputs = mrgCfg.Outputs
+	}
 
 	if mrgCfg.Checks == nil {
 		return nil
@@ -94,6 +205,36 @@ func (cfg *Config) Merge(mrgCfg Config) error {
 	return nil
 }
 
+// ApplySeverityOverrides changes the severity of the checks selected by the
+// overrides defined for the environment. A check is selected by its name,
+// then by its tags in order, then by its type.
+func (cfg *Config) ApplySeverityOverrides(env string) error {
+	overrides, ok := cfg.SeverityOverrides[env]
+	if !ok {
+		return nil
+	}
+	for selector, s := range overrides {
+		if !IsValidSeverity(s) {
+			return fmt.Errorf("invalid severity '%s' for '%s' in the '%s' severity overrides",
+				s, selector, env)
+		}
+	}
+
+	for ct, checks := range cfg.Checks {
+		for _, c := range checks {
+			selectors := append([]string{c.GetName()}, c.GetTags()...)
+			selectors = append(selectors, string(ct))
+			for _, selector := range selectors {
+				if s, ok := overrides[selector]; ok {
+					c.SetSeverity(s)
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // FilterChecksToRun iterates over all the checks and filters them based on
 // a provided list of check types to run or whether to exclude database checks.
 func (cfg *Config) FilterChecksToRun(checkTypesToRun []string, excludeDb bool) {
@@ -102,9 +243,11 @@ func (cfg *Config) FilterChecksToRun(checkTypesToRun []string, excludeDb bool) {
 		newChecks := []Check{}
 		for _, c := range checks {
 			if len(checkTypesToRun) > 0 && !utils.StringSliceContains(checkTypesToRun, string(ct)) {
+				cfg.skipCheck(c, "check type not selected")
 				continue
 			}
 			if excludeDb && c.RequiresDatabase() {
+				cfg.skipCheck(c, "database checks excluded")
 				continue
 			}
 			newChecks = append(newChecks, c)
@@ -115,3 +258,10 @@ func (cfg *Config) FilterChecksToRun(checkTypesToRun []string, excludeDb bool) {
 	}
 	cfg.Checks = newCm
 }
+
+func (cfg *Config) skipCheck(c Check, reason string) {
+	if cfg.SkippedChecks == nil {
+		cfg.SkippedChecks = map[Check]string{}
+	}
+	cfg.SkippedChecks[c] = reason
+}
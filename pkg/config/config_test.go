@@ -7,6 +7,7 @@ import (
 	"github.com/salsadigitalauorg/shipshape/pkg/config/testdata/filterchecks"
 	"github.com/salsadigitalauorg/shipshape/pkg/config/testdata/testchecks"
 	"github.com/salsadigitalauorg/shipshape/pkg/config/testdata/testchecks_invalid"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -76,6 +77,49 @@ foo:
 	})
 }
 
+func TestParseConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewRegistry()
+	assert.NoError(registry.Register(testchecks.TestCheck2,
+		func() Check { return &testchecks.TestCheck2Check{} }, Meta{}))
+	cfg, err := ParseConfig([]byte(`
+project-dir: /site
+fail-severity: critical
+checks:
+  test-check-1:
+    - name: Not in registry
+  test-check-2:
+    - name: My test check 2
+      bar: zoom
+`), registry)
+	assert.NoError(err)
+	assert.Equal("/site", cfg.ProjectDir)
+	assert.Equal(CriticalSeverity, cfg.FailSeverity)
+	assert.Equal(CheckMap{
+		testchecks.TestCheck2: {
+			&testchecks.TestCheck2Check{
+				CheckBase: CheckBase{Name: "My test check 2"},
+				Bar:       "zoom",
+			},
+		},
+	}, cfg.Checks)
+
+	_, err = ParseConfig([]byte("checks:\n  test-check-2: foo\n"), registry)
+	assert.EqualError(err, "list required under check type 'test-check-2', got !!str instead")
+
+	_, err = ParseConfig([]byte("fail-severity: severe\n"), registry)
+	assert.EqualError(err, "invalid fail-severity 'severe'")
+
+	_, err = ParseConfig([]byte(`
+fail-policy:
+  check-types:
+    file:
+      fail-severity: severe
+`), registry)
+	assert.EqualError(err, "invalid fail-severity 'severe' for 'file' in the fail policy")
+}
+
 func TestMerge(t *testing.T) {
 	assert := assert.New(t)
 
@@ -265,6 +309,7 @@ func TestFilterChecksToRun(t *testing.T) {
 				c.Init(ct)
 			}
 		}
+		skippedCheck := cfg.Checks[filterchecks.FilterCheck2][0]
 		cfg.FilterChecksToRun([]string{"filter-check-1"}, false)
 
 		expectedCheck := &filterchecks.FilterCheck1Check{
@@ -272,7 +317,8 @@ func TestFilterChecksToRun(t *testing.T) {
 		}
 		expectedCheck.Init(filterchecks.FilterCheck1)
 		assert.EqualValues(Config{
-			Checks: CheckMap{filterchecks.FilterCheck1: {expectedCheck}},
+			Checks:        CheckMap{filterchecks.FilterCheck1: {expectedCheck}},
+			SkippedChecks: map[Check]string{skippedCheck: "check type not selected"},
 		}, cfg)
 	})
 
@@ -296,6 +342,7 @@ func TestFilterChecksToRun(t *testing.T) {
 				c.Init(ct)
 			}
 		}
+		skippedCheck := cfg.Checks[filterchecks.FilterCheck1][0]
 		cfg.FilterChecksToRun([]string(nil), true)
 
 		expectedCheck := &filterchecks.FilterCheck2Check{
@@ -303,7 +350,142 @@ func TestFilterChecksToRun(t *testing.T) {
 		}
 		expectedCheck.Init(filterchecks.FilterCheck2)
 		assert.EqualValues(Config{
-			Checks: CheckMap{filterchecks.FilterCheck2: {expectedCheck}},
+			Checks:        CheckMap{filterchecks.FilterCheck2: {expectedCheck}},
+			SkippedChecks: map[Check]string{skippedCheck: "database checks excluded"},
 		}, cfg)
 	})
 }
+
+func TestEvaluateFailPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := result.ResultList{Results: []result.Result{
+		{CheckType: "file", Breaches: []result.Breach{
+			&result.ValueBreach{Severity: "critical"},
+			&result.ValueBreach{Severity: "normal"},
+			&result.ValueBreach{Severity: "normal"},
+		}},
+		{CheckType: "phpstan", Breaches: []result.Breach{
+			&result.ValueBreach{Severity: "high"},
+			&result.ValueBreach{Severity: "high"},
+		}},
+		{CheckType: "yaml", Breaches: []result.Breach{
+			&result.ValueBreach{
+				Severity:    "critical",
+				Remediation: result.Remediation{Status: result.RemediationStatusSuccess},
+			},
+		}},
+	}}
+
+	t.Run("atOrAboveSeverity", func(t *testing.T) {
+		cfg := Config{FailSeverity: HighSeverity}
+		pe := cfg.EvaluateFailPolicy(&rl)
+		assert.True(pe.Failed)
+		assert.Equal([]result.PolicyRuleEvaluation{
+			{Severity: "critical", Breaches: 1, MaxBreaches: 0, Failed: true},
+			{Severity: "high", Breaches: 2, MaxBreaches: 0, Failed: true},
+		}, pe.Rules)
+	})
+
+	t.Run("defaultSeverity", func(t *testing.T) {
+		cfg := Config{}
+		pe := cfg.EvaluateFailPolicy(&result.ResultList{Results: []result.Result{
+			{CheckType: "file", Breaches: []result.Breach{&result.ValueBreach{Severity: "normal"}}},
+		}})
+		assert.False(pe.Failed)
+		assert.Equal("high", pe.FailSeverity)
+	})
+
+	t.Run("maxBreachesAndOverrides", func(t *testing.T) {
+		cfg := Config{
+			FailSeverity: CriticalSeverity,
+			FailPolicy: FailPolicy{
+				MaxBreaches: map[Severity]int{NormalSeverity: 1, CriticalSeverity: 1},
+				CheckTypes: map[CheckType]FailPolicyOverride{
+					"phpstan": {FailSeverity: HighSeverity, MaxBreaches: map[Severity]int{HighSeverity: 5}},
+				},
+			},
+		}
+		pe := cfg.EvaluateFailPolicy(&rl)
+		assert.True(pe.Failed)
+		assert.Equal([]result.PolicyRuleEvaluation{
+			{Severity: "critical", Breaches: 1, MaxBreaches: 1},
+			{Severity: "normal", Breaches: 2, MaxBreaches: 1, Failed: true},
+			{CheckType: "phpstan", Severity: "critical", Breaches: 0, MaxBreaches: 1},
+			{CheckType: "phpstan", Severity: "high", Breaches: 2, MaxBreaches: 5},
+			{CheckType: "phpstan", Severity: "normal", Breaches: 0, MaxBreaches: 1},
+		}, pe.Rules)
+		assert.Equal([]result.PolicyRuleEvaluation{
+			{Severity: "normal", Breaches: 2, MaxBreaches: 1, Failed: true},
+		}, pe.FailedRules())
+		assert.Equal("Fail policy failed (fail severity: critical); 1 rule(s) exceeded", pe.Summary())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		errRl := result.ResultList{Results: []result.Result{
+			{CheckType: "file", Errors: []string{"check panicked: boom"}},
+			{CheckType: "yaml"},
+		}}
+		cfg := Config{}
+		pe := cfg.EvaluateFailPolicy(&errRl)
+		assert.True(pe.Failed)
+		assert.Equal(1, pe.Errors)
+		assert.Empty(pe.FailedRules())
+		assert.Equal("Fail policy failed (fail severity: high); 1 check(s) with errors", pe.Summary())
+
+		cfg = Config{FailPolicy: FailPolicy{AllowErrors: true}}
+		pe = cfg.EvaluateFailPolicy(&errRl)
+		assert.False(pe.Failed)
+		assert.Equal(1, pe.Errors)
+	})
+}
+
+func TestApplySeverityOverrides(t *testing.T) {
+	assert := assert.New(t)
+
+	newCfg := func() Config {
+		return Config{
+			Checks: CheckMap{
+				testchecks.TestCheck1: {
+					&testchecks.TestCheck1Check{CheckBase: CheckBase{Name: "check1", Severity: HighSeverity}},
+					&testchecks.TestCheck1Check{CheckBase: CheckBase{Name: "check2", Tags: []string{"security"}}},
+				},
+				testchecks.TestCheck2: {
+					&testchecks.TestCheck2Check{CheckBase: CheckBase{Name: "check3", Tags: []string{"security"}}},
+				},
+			},
+			SeverityOverrides: map[string]map[string]Severity{
+				"production": {"check1": CriticalSeverity, "security": HighSeverity},
+				"development": {
+					string(testchecks.TestCheck1): LowSeverity,
+					string(testchecks.TestCheck2): LowSeverity,
+					"check3":                      NormalSeverity,
+				},
+				"invalid": {"check1": "medium"},
+			},
+		}
+	}
+	severities := func(cfg Config) []Severity {
+		return []Severity{
+			cfg.Checks[testchecks.TestCheck1][0].GetSeverity(),
+			cfg.Checks[testchecks.TestCheck1][1].GetSeverity(),
+			cfg.Checks[testchecks.TestCheck2][0].GetSeverity(),
+		}
+	}
+
+	cfg := newCfg()
+	assert.NoError(cfg.ApplySeverityOverrides("production"))
+	assert.Equal([]Severity{CriticalSeverity, HighSeverity, HighSeverity}, severities(cfg))
+
+	cfg = newCfg()
+	assert.NoError(cfg.ApplySeverityOverrides("development"))
+	assert.Equal([]Severity{LowSeverity, LowSeverity, NormalSeverity}, severities(cfg))
+
+	cfg = newCfg()
+	assert.NoError(cfg.ApplySeverityOverrides("unknown"))
+	assert.Equal([]Severity{HighSeverity, "", ""}, severities(cfg))
+
+	cfg = newCfg()
+	assert.EqualError(cfg.ApplySeverityOverrides("invalid"),
+		"invalid severity 'medium' for 'check1' in the 'invalid' severity overrides")
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// FailPolicy refines when a run is considered failed, on top of the fail
+// severity.
+type FailPolicy struct {
+	// Maximum number of breaches allowed for a severity; severities at or
+	// above the fail severity allow none by default, the ones below are not
+	// considered unless they have a maximum.
+	MaxBreaches map[Severity]int `yaml:"max-breaches,omitempty"`
+	// Overrides for specific check types, whose breaches are then evaluated
+	// separately.
+	CheckTypes map[CheckType]FailPolicyOverride `yaml:"check-types,omitempty"`
+	// Errors encountered while executing checks fail the run unless allowed.
+	AllowErrors bool `yaml:"allow-errors,omitempty"`
+}
+
+// FailPolicyOverride is the fail policy for a check type; the fail severity
+// and maximums default to the global ones.
+type FailPolicyOverride struct {
+	FailSeverity Severity         `yaml:"fail-severity,omitempty"`
+	MaxBreaches  map[Severity]int `yaml:"max-breaches,omitempty"`
+}
+
+// SeverityIndex returns the position of the severity in Severities, or -1 for
+// an unknown severity.
+func SeverityIndex(s Severity) int {
+	for i, sv := range Severities {
+		if sv == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValidSeverity determines whether the severity is a known one.
+func IsValidSeverity(s Severity) bool {
+	return SeverityIndex(s) != -1
+}
+
+// EvaluateFailPolicy counts the non-remediated breaches by severity and
+// compares them to the maximum allowed by the policy; checks with errors
+// fail it unless they are allowed.
+func (cfg *Config) EvaluateFailPolicy(rl *result.ResultList) *result.PolicyEvaluation {
+	failSeverity := cfg.FailSeverity
+	if failSeverity == "" {
+		failSeverity = HighSeverity
+	}
+	pe := &result.PolicyEvaluation{
+		FailSeverity:  string(failSeverity),
+		ErrorsAllowed: cfg.FailPolicy.AllowErrors,
+	}
+
+	globalCounts := map[Severity]int{}
+	typeCounts := map[CheckType]map[Severity]int{}
+	for _, r := range rl.Results {
+		if len(r.Errors) > 0 {
+			pe.Errors++
+		}
+		ct := CheckType(r.CheckType)
+		counts := globalCounts
+		if _, ok := cfg.FailPolicy.CheckTypes[ct]; ok {
+			if typeCounts[ct] == nil {
+				typeCounts[ct] = map[Severity]int{}
+			}
+			counts = typeCounts[ct]
+		}
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			counts[Severity(b.GetSeverity())]++
+		}
+	}
+
+	pe.Rules = evaluateFailRules("", failSeverity, cfg.FailPolicy.MaxBreaches, globalCounts)
+
+	checkTypes := []string{}
+	for ct := range cfg.FailPolicy.CheckTypes {
+		checkTypes = append(checkTypes, string(ct))
+	}
+	sort.Strings(checkTypes)
+	for _, ct := range checkTypes {
+		o := cfg.FailPolicy.CheckTypes[CheckType(ct)]
+		sv := o.FailSeverity
+		if sv == "" {
+			sv = failSeverity
+		}
+		max := map[Severity]int{}
+		for s, m := range cfg.FailPolicy.MaxBreaches {
+			max[s] = m
+		}
+		for s, m := range o.MaxBreaches {
+			max[s] = m
+		}
+		pe.Rules = append(pe.Rules, evaluateFailRules(ct, sv, max, typeCounts[CheckType(ct)])...)
+	}
+
+	pe.Failed = pe.ErrorsFailed()
+	for _, re := range pe.Rules {
+		if re.Failed {
+			pe.Failed = true
+		}
+	}
+	return pe
+}
+
+// evaluateFailRules generates a rule for each severity considered by the
+// policy, from the highest.
+func evaluateFailRules(ct string, failSeverity Severity, maxBreaches map[Severity]int, counts map[Severity]int) []result.PolicyRuleEvaluation {
+	rules := []result.PolicyRuleEvaluation{}
+	for i := len(Severities) - 1; i >= 0; i-- {
+		sv := Severities[i]
+		max, hasMax := maxBreaches[sv]
+		if !hasMax && i < SeverityIndex(failSeverity) {
+			continue
+		}
+		rules = append(rules, result.PolicyRuleEvaluation{
+			CheckType:   ct,
+			Severity:    string(sv),
+			Breaches:    counts[sv],
+			MaxBreaches: max,
+			Failed:      counts[sv] > max,
+		})
+	}
+	return rules
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Meta describes a check type, e.g. for --list-checks.
+type Meta struct {
+	Description string
+	// Whether the check requires a database and supports remediation are
+	// determined from an instance of the check when registering it.
+	RequiresDb          bool
+	SupportsRemediation bool
+	// The config fields specific to the check type; defaults to the yaml
+	// fields of the check's struct, excluding the common ones.
+	Fields []string
+}
+
+type registryEntry struct {
+	factory func() Check
+	meta    Meta
+	// The plugin implementing the check type, if any.
+	plugin *Plugin
+}
+
+// Registry holds the check types which can be used in the config; it is safe
+// for concurrent use.
+type Registry struct {
+	lock    sync.RWMutex
+	entries map[CheckType]registryEntry
+}
+
+// ChecksRegistry is the registry of the built-in check types, to which
+// check packages add theirs when imported.
+var ChecksRegistry = NewRegistry()
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: map[CheckType]registryEntry{}}
+}
+
+// Clone returns a new registry with the check types of this one, to which
+// other types can be added without affecting it.
+func (r *Registry) Clone() *Registry {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	clone := NewRegistry()
+	for ct, e := range r.entries {
+		clone.entries[ct] = e
+	}
+	return clone
+}
+
+// Register adds a check type to ChecksRegistry.
+func Register(ct CheckType, factory func() Check, meta Meta) error {
+	return ChecksRegistry.Register(ct, factory, meta)
+}
+
+// MustRegister adds a check type to ChecksRegistry, panicking if it cannot
+// be registered; it is meant to be used in init functions.
+func MustRegister(ct CheckType, factory func() Check, meta Meta) {
+	if err := Register(ct, factory, meta); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds a check type to the registry; a type can only be registered
+// once, so that it cannot be overwritten silently.
+func (r *Registry) Register(ct CheckType, factory func() Check, meta Meta) error {
+	e, err := newRegistryEntry(ct, factory, meta)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.entries[ct]; ok {
+		return fmt.Errorf("check type '%s' is already registered", ct)
+	}
+	r.entries[ct] = e
+	return nil
+}
+
+// RegisterPlugin adds the check type implemented by a plugin to the
+// registry. Registering the same plugin again is a no-op, so that the config
+// can be parsed more than once, but a check type cannot be replaced by a
+// different plugin.
+func (r *Registry) RegisterPlugin(p Plugin, factory func() Check, meta Meta) error {
+	e, err := newRegistryEntry(p.Type, factory, meta)
+	if err != nil {
+		return err
+	}
+	e.plugin = &p
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if existing, ok := r.entries[p.Type]; ok {
+		if existing.plugin == nil {
+			return fmt.Errorf("check type '%s' is already registered", p.Type)
+		}
+		if reflect.DeepEqual(*existing.plugin, p) {
+			return nil
+		}
+		return fmt.Errorf("plugin '%s' is already registered with a different command", p.Type)
+	}
+	r.entries[p.Type] = e
+	return nil
+}
+
+// newRegistryEntry validates the check type and determines its metadata from
+// an instance of the check.
+func newRegistryEntry(ct CheckType, factory func() Check, meta Meta) (registryEntry, error) {
+	if ct == "" {
+		return registryEntry{}, fmt.Errorf("check type required")
+	}
+	if factory == nil {
+		return registryEntry{}, fmt.Errorf("factory required for check type '%s'", ct)
+	}
+	c := factory()
+	if meta.Fields == nil {
+		meta.Fields = checkFields(reflect.TypeOf(c))
+	}
+	c.Init(ct)
+	meta.RequiresDb = c.RequiresDatabase()
+	rs, ok := c.(RemediationSupporter)
+	meta.SupportsRemediation = ok && rs.SupportsRemediation()
+	return registryEntry{factory: factory, meta: meta}, nil
+}
+
+// Get returns the factory of a check type.
+func (r *Registry) Get(ct CheckType) (func() Check, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	e, ok := r.entries[ct]
+	return e.factory, ok
+}
+
+// Meta returns the metadata of a check type.
+func (r *Registry) Meta(ct CheckType) (Meta, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	e, ok := r.entries[ct]
+	return e.meta, ok
+}
+
+// Types returns the registered check types, sorted.
+func (r *Registry) Types() []CheckType {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	types := []CheckType{}
+	for ct := range r.entries {
+		types = append(types, ct)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// checkFields lists the named yaml fields of a check's struct, including the
+// ones of inlined structs other than CheckBase.
+func checkFields(t reflect.Type) []string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	fields := []string{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if opts == "inline" {
+			if f.Type != reflect.TypeOf(CheckBase{}) {
+				fields = append(fields, checkFields(f.Type)...)
+			}
+			continue
+		}
+		// Fields without a yaml name hold internal state.
+		if !f.IsExported() || name == "" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
@@ -0,0 +1,102 @@
+package config_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/config/testdata/testchecks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type remediatingCheck struct {
+	testchecks.TestCheck2Check `yaml:",inline"`
+}
+
+func (c *remediatingCheck) Init(ct CheckType) {
+	c.CheckBase.Init(ct)
+	c.RequiresDb = true
+}
+
+func (c *remediatingCheck) SupportsRemediation() bool { return true }
+
+func TestRegistry(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewRegistry()
+	err := r.Register(testchecks.TestCheck2, func() Check { return &testchecks.TestCheck2Check{} }, Meta{
+		Description: "Second test check",
+		// Determined from the check.
+		RequiresDb:          true,
+		SupportsRemediation: true,
+	})
+	assert.NoError(err)
+
+	factory, ok := r.Get(testchecks.TestCheck2)
+	assert.True(ok)
+	assert.IsType(&testchecks.TestCheck2Check{}, factory())
+	meta, ok := r.Meta(testchecks.TestCheck2)
+	assert.True(ok)
+	assert.Equal(Meta{
+		Description: "Second test check",
+		Fields:      []string{"bar"},
+	}, meta)
+
+	assert.NoError(r.Register("remediating", func() Check { return &remediatingCheck{} }, Meta{}))
+	meta, _ = r.Meta("remediating")
+	assert.Equal(Meta{
+		RequiresDb:          true,
+		SupportsRemediation: true,
+		Fields:              []string{"bar"},
+	}, meta)
+	_, ok = r.Get(testchecks.TestCheck1)
+	assert.False(ok)
+
+	err = r.Register(testchecks.TestCheck2, func() Check { return &testchecks.TestCheck1Check{} }, Meta{})
+	assert.EqualError(err, "check type 'test-check-2' is already registered")
+	factory, _ = r.Get(testchecks.TestCheck2)
+	assert.IsType(&testchecks.TestCheck2Check{}, factory())
+
+	assert.EqualError(r.Register("", func() Check { return nil }, Meta{}), "check type required")
+	assert.EqualError(r.Register("foo", nil, Meta{}), "factory required for check type 'foo'")
+}
+
+func TestRegistryClone(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewRegistry()
+	assert.NoError(r.Register(testchecks.TestCheck1, func() Check { return &testchecks.TestCheck1Check{} }, Meta{}))
+	clone := r.Clone()
+	assert.NoError(clone.Register(testchecks.TestCheck2, func() Check { return &testchecks.TestCheck2Check{} }, Meta{}))
+	assert.Equal([]CheckType{testchecks.TestCheck1, testchecks.TestCheck2}, clone.Types())
+	assert.Equal([]CheckType{testchecks.TestCheck1}, r.Types())
+}
+
+func TestRegistryConcurrentRegister(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = r.Register(testchecks.TestCheck1,
+				func() Check { return &testchecks.TestCheck1Check{} }, Meta{})
+			r.Types()
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	assert.Equal(9, failed)
+	assert.Equal([]CheckType{testchecks.TestCheck1}, r.Types())
+}
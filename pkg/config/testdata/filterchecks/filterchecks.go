@@ -1,6 +1,8 @@
 package filterchecks
 
 import (
+	"sync"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 )
 
@@ -22,7 +24,13 @@ type FilterCheck2Check struct {
 	Bar              string `yaml:"bar"`
 }
 
+var registerOnce sync.Once
+
+// RegisterChecks adds the test check types to the registry; it can be called
+// by multiple tests.
 func RegisterChecks() {
-	config.ChecksRegistry[FilterCheck1] = func() config.Check { return &FilterCheck1Check{} }
-	config.ChecksRegistry[FilterCheck2] = func() config.Check { return &FilterCheck2Check{} }
+	registerOnce.Do(func() {
+		config.MustRegister(FilterCheck1, func() config.Check { return &FilterCheck1Check{} }, config.Meta{})
+		config.MustRegister(FilterCheck2, func() config.Check { return &FilterCheck2Check{} }, config.Meta{})
+	})
 }
@@ -1,6 +1,8 @@
 package testchecks
 
 import (
+	"sync"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/utils"
 )
@@ -37,8 +39,14 @@ type TestCheck3Check struct {
 	Bar              string `yaml:"bar"`
 }
 
+var registerOnce sync.Once
+
+// RegisterChecks adds the test check types to the registry; it can be called
+// by multiple tests.
 func RegisterChecks() {
-	config.ChecksRegistry[TestCheck1] = func() config.Check { return &TestCheck1Check{} }
-	config.ChecksRegistry[TestCheck2] = func() config.Check { return &TestCheck2Check{} }
-	config.ChecksRegistry[TestCheck3] = func() config.Check { return &TestCheck3Check{} }
+	registerOnce.Do(func() {
+		config.MustRegister(TestCheck1, func() config.Check { return &TestCheck1Check{} }, config.Meta{})
+		config.MustRegister(TestCheck2, func() config.Check { return &TestCheck2Check{} }, config.Meta{})
+		config.MustRegister(TestCheck3, func() config.Check { return &TestCheck3Check{} }, config.Meta{})
+	})
 }
@@ -1,6 +1,8 @@
 package testchecks_invalid
 
 import (
+	"sync"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 )
 
@@ -11,6 +13,12 @@ type TestCheckInvalidCheck struct {
 	Zoom             string `yaml:"zap"`
 }
 
+var registerOnce sync.Once
+
+// RegisterChecks adds the test check types to the registry; it can be called
+// by multiple tests.
 func RegisterChecks() {
-	config.ChecksRegistry[TestCheckInvalid] = func() config.Check { return &TestCheckInvalidCheck{} }
+	registerOnce.Do(func() {
+		config.MustRegister(TestCheckInvalid, func() config.Check { return &TestCheckInvalidCheck{} }, config.Meta{})
+	})
 }
@@ -1,18 +1,66 @@
 package config
 
-import "github.com/salsadigitalauorg/shipshape/pkg/result"
+import (
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
 
 type Config struct {
 	// The directory to audit.
 	ProjectDir string `yaml:"project-dir"`
+	// Glob patterns of the directories to check in a single run; ignored
+	// when directories are provided as arguments.
+	Projects []string `yaml:"projects,omitempty"`
 	// The severity level for which the program will exit with an error.
 	// Default is high.
-	FailSeverity Severity `yaml:"fail-severity"`
-	Checks       CheckMap `yaml:"checks"`
-	Remediate    bool     `yaml:"-"`
+	FailSeverity Severity   `yaml:"fail-severity"`
+	FailPolicy   FailPolicy `yaml:"fail-policy,omitempty"`
+	Checks       CheckMap   `yaml:"checks"`
+	// Severities to apply per environment, keyed by environment then by
+	// check name, tag or type.
+	SeverityOverrides map[string]map[string]Severity `yaml:"severity-overrides,omitempty"`
+	// Checks which were filtered out and will not be run, along with the
+	// reason; see FilterChecksToRun.
+	SkippedChecks map[Check]string `yaml:"-"`
+	Remediate     bool             `yaml:"-"`
 	// If requesting LagoonFact output, the base url and token for the Lagoon
 	// api are required to infer environment IDs and the like.
 	LagoonApiBaseUrl string `yaml:"lagoon-api-base-url"`
+	// The list of output formats to render, and optionally the file each one
+	// should be written to. Overridden by the --output flag.
+	Outputs []Output `yaml:"outputs"`
+	// Check types implemented by external executables, either listed or
+	// found in a directory.
+	Plugins    []Plugin `yaml:"plugins,omitempty"`
+	PluginsDir string   `yaml:"plugins-dir,omitempty"`
+}
+
+// Plugin defines a check type implemented by an external executable, which
+// receives the check's config as JSON on its stdin and writes the result as
+// JSON on its stdout.
+type Plugin struct {
+	Type CheckType `yaml:"type"`
+	// The executable and its arguments; a relative path is resolved against
+	// the current directory.
+	Command             string   `yaml:"command"`
+	Args                []string `yaml:"args,omitempty"`
+	Description         string   `yaml:"description,omitempty"`
+	RequiresDb          bool     `yaml:"requires-db,omitempty"`
+	SupportsRemediation bool     `yaml:"supports-remediation,omitempty"`
+	// The maximum duration of each run of the plugin; no limit if zero.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Output defines a format in which the results are rendered, and its
+// destination; an empty File means stdout.
+type Output struct {
+	Format string `yaml:"format"`
+	File   string `yaml:"file,omitempty"`
+	// Path to the Go text/template file used by the template format.
+	Template string `yaml:"template,omitempty"`
+	// File to which the CI formats attribute breaches without a location.
+	LocationFile string `yaml:"-"`
 }
 
 type Severity string
@@ -24,6 +72,9 @@ const (
 	CriticalSeverity Severity = "critical"
 )
 
+// Severities lists the severity levels, from lowest to highest.
+var Severities = []Severity{LowSeverity, NormalSeverity, HighSeverity, CriticalSeverity}
+
 type CheckMap map[CheckType][]Check
 
 type CheckType string
@@ -36,6 +87,10 @@ type Check interface {
 	GetName() string
 	GetType() CheckType
 	GetSeverity() Severity
+	SetSeverity(s Severity)
+	GetTags() []string
+	SetProjectDir(dir string)
+	GetProjectDir() string
 	Merge(Check) error
 	RequiresData() bool
 	RequiresDatabase() bool
@@ -45,6 +100,7 @@ type Check interface {
 	AddBreach(result.Breach)
 	AddPass(msg string)
 	AddWarning(msg string)
+	AddError(msg string)
 	SetPerformRemediation(flag bool)
 	RunCheck()
 	ShouldPerformRemediation() bool
@@ -52,6 +108,23 @@ type Check interface {
 	GetResult() *result.Result
 }
 
+// RemediationSupporter is implemented by checks which can remediate their
+// breaches; the others rely on CheckBase.Remediate, which reports the
+// remediation as not supported.
+type RemediationSupporter interface {
+	SupportsRemediation() bool
+}
+
+// ResultsConsumer is implemented by checks which use the results of other
+// checks; they are run once all the other checks have completed, and after
+// the consumers they depend on.
+type ResultsConsumer interface {
+	// ResultsDependencies returns the names of the checks whose results are
+	// used.
+	ResultsDependencies() []string
+	SetResults(results []result.Result)
+}
+
 // CheckBase provides the basic structure for all Checks.
 type CheckBase struct {
 	Name  string `yaml:"name"`
@@ -61,6 +134,20 @@ type CheckBase struct {
 	DataMap    map[string][]byte `yaml:"-"`
 	Result     result.Result     `yaml:"-"`
 	// Default severity is normal.
-	Severity           `yaml:"severity"`
-	PerformRemediation bool `yaml:"-"`
+	Severity `yaml:"severity"`
+	// Arbitrary labels, which can be used to select checks in the severity
+	// overrides.
+	Tags []string `yaml:"tags,omitempty"`
+	// Optional metadata explaining the check to the people fixing breaches;
+	// it is copied to the result.
+	Description         string   `yaml:"description,omitempty"`
+	Rationale           string   `yaml:"rationale,omitempty"`
+	References          []string `yaml:"references,omitempty"`
+	RemediationGuidance string   `yaml:"remediation-guidance,omitempty"`
+	// Identifiers of the compliance framework controls the check verifies,
+	// e.g. ISM-1173; used by the compliance output.
+	Controls           []string `yaml:"controls,omitempty"`
+	PerformRemediation bool     `yaml:"-"`
+	// Directory of the project being checked; see GetProjectDir.
+	projectDir string
 }
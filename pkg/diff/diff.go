@@ -0,0 +1,186 @@
+// Package diff compares the results of two runs, as produced by the json
+// output, to determine which breaches were added and resolved.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// CheckDiff holds the breaches of a check, compared by fingerprint.
+type CheckDiff struct {
+	CheckType string
+	CheckName string
+	// The project of the check's results, in batch and merged runs.
+	Project   string
+	Added     []result.Breach
+	Resolved  []result.Breach
+	Unchanged []result.Breach
+}
+
+// LoadResultList reads a result file generated by the json output.
+func LoadResultList(file string) (result.ResultList, error) {
+	rl := result.ResultList{}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return rl, err
+	}
+	if err := json.Unmarshal(data, &rl); err != nil {
+		return rl, fmt.Errorf("invalid result file '%s': %w", file, err)
+	}
+	return rl, nil
+}
+
+// ResultFileLabels labels result files by their name without extension, or
+// by their path when names collide; the same file cannot be given twice.
+func ResultFileLabels(files []string) ([]string, error) {
+	byName := func(f string) string {
+		return strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+	}
+	byPath := func(f string) string {
+		f = filepath.ToSlash(filepath.Clean(f))
+		return strings.TrimSuffix(f, filepath.Ext(f))
+	}
+	for _, labelFn := range []func(string) string{byName, byPath} {
+		labels := make([]string, 0, len(files))
+		seen := map[string]bool{}
+		for _, f := range files {
+			l := labelFn(f)
+			if seen[l] {
+				break
+			}
+			seen[l] = true
+			labels = append(labels, l)
+		}
+		if len(labels) == len(files) {
+			return labels, nil
+		}
+	}
+	return nil, fmt.Errorf("result files have duplicate labels: %s", strings.Join(files, ", "))
+}
+
+// Compare determines the breaches added, resolved and unchanged between two
+// result lists, for each check; successfully remediated breaches are
+// considered resolved.
+func Compare(old result.ResultList, new result.ResultList) []CheckDiff {
+	diffs := map[string]*CheckDiff{}
+	getDiff := func(r result.Result) *CheckDiff {
+		key := r.CheckType + "\x1e" + r.Name + "\x1e" + r.Project
+		if _, ok := diffs[key]; !ok {
+			diffs[key] = &CheckDiff{CheckType: r.CheckType, CheckName: r.Name, Project: r.Project}
+		}
+		return diffs[key]
+	}
+
+	oldBreaches := breachesByFingerprint(old)
+	newBreaches := breachesByFingerprint(new)
+	for _, r := range new.Results {
+		d := getDiff(r)
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			if _, ok := oldBreaches[result.ProjectBreachFingerprint(r.Project, b)]; ok {
+				d.Unchanged = append(d.Unchanged, b)
+			} else {
+				d.Added = append(d.Added, b)
+			}
+		}
+	}
+	for _, r := range old.Results {
+		d := getDiff(r)
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			if _, ok := newBreaches[result.ProjectBreachFingerprint(r.Project, b)]; !ok {
+				d.Resolved = append(d.Resolved, b)
+			}
+		}
+	}
+
+	checkDiffs := []CheckDiff{}
+	for _, d := range diffs {
+		if len(d.Added)+len(d.Resolved)+len(d.Unchanged) == 0 {
+			continue
+		}
+		checkDiffs = append(checkDiffs, *d)
+	}
+	sort.Slice(checkDiffs, func(i, j int) bool {
+		if checkDiffs[i].CheckType != checkDiffs[j].CheckType {
+			return checkDiffs[i].CheckType < checkDiffs[j].CheckType
+		}
+		if checkDiffs[i].CheckName != checkDiffs[j].CheckName {
+			return checkDiffs[i].CheckName < checkDiffs[j].CheckName
+		}
+		return checkDiffs[i].Project < checkDiffs[j].Project
+	})
+	return checkDiffs
+}
+
+// AddedAtOrAbove returns the added breaches with a severity at or above the
+// one provided.
+func AddedAtOrAbove(diffs []CheckDiff, severity config.Severity) []result.Breach {
+	breaches := []result.Breach{}
+	for _, d := range diffs {
+		for _, b := range d.Added {
+			if config.SeverityIndex(config.Severity(b.GetSeverity())) >= config.SeverityIndex(severity) {
+				breaches = append(breaches, b)
+			}
+		}
+	}
+	return breaches
+}
+
+// Display outputs the added and resolved breaches of each check, along with
+// the number of unchanged ones.
+func Display(w io.Writer, diffs []CheckDiff) {
+	added, resolved, unchanged := 0, 0, 0
+	for _, d := range diffs {
+		added += len(d.Added)
+		resolved += len(d.Resolved)
+		unchanged += len(d.Unchanged)
+	}
+	fmt.Fprintf(w, "%d added, %d resolved, %d unchanged breach(es)\n", added, resolved, unchanged)
+
+	for _, d := range diffs {
+		if len(d.Added) == 0 && len(d.Resolved) == 0 {
+			continue
+		}
+		if d.Project != "" {
+			fmt.Fprintf(w, "\n  ### %s (%s) [%s]\n", d.CheckName, d.CheckType, d.Project)
+		} else {
+			fmt.Fprintf(w, "\n  ### %s (%s)\n", d.CheckName, d.CheckType)
+		}
+		for _, b := range d.Added {
+			fmt.Fprintf(w, "     + [%s] %s\n", b.GetSeverity(), b)
+		}
+		for _, b := range d.Resolved {
+			fmt.Fprintf(w, "     - [%s] %s\n", b.GetSeverity(), b)
+		}
+		if len(d.Unchanged) > 0 {
+			fmt.Fprintf(w, "     = %d unchanged\n", len(d.Unchanged))
+		}
+	}
+}
+
+func breachesByFingerprint(rl result.ResultList) map[string]result.Breach {
+	breaches := map[string]result.Breach{}
+	for _, r := range rl.Results {
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			breaches[result.ProjectBreachFingerprint(r.Project, b)] = b
+		}
+	}
+	return breaches
+}
@@ -0,0 +1,127 @@
+package diff_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	. "github.com/salsadigitalauorg/shipshape/pkg/diff"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newResultList(severity string, values ...string) result.ResultList {
+	rl := result.NewResultList(false)
+	r := result.Result{Name: "illegal files", CheckType: "file", Severity: severity}
+	for _, v := range values {
+		b := &result.ValueBreach{Value: v}
+		b.SetCommonValues("file", "illegal files", severity)
+		r.Breaches = append(r.Breaches, b)
+	}
+	rl.AddResult(r)
+	return rl
+}
+
+func TestLoadResultList(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := newResultList("high", "adminer.php")
+	data, _ := json.Marshal(rl)
+	file := filepath.Join(t.TempDir(), "result.json")
+	assert.NoError(os.WriteFile(file, data, 0644))
+
+	loaded, err := LoadResultList(file)
+	assert.NoError(err)
+	assert.Equal(rl, loaded)
+
+	assert.NoError(os.WriteFile(file, []byte("{"), 0644))
+	_, err = LoadResultList(file)
+	assert.ErrorContains(err, "invalid result file")
+}
+
+// newProjectsResultList creates a list with the breaches of each project.
+func newProjectsResultList(breaches map[string][]string) result.ResultList {
+	rl := result.NewResultList(false)
+	for project, values := range breaches {
+		r := result.Result{Name: "illegal files", CheckType: "file", Severity: "high", Project: project}
+		for _, v := range values {
+			b := &result.ValueBreach{Value: v}
+			b.SetCommonValues("file", "illegal files", "high")
+			r.Breaches = append(r.Breaches, b)
+		}
+		rl.AddResult(r)
+	}
+	return rl
+}
+
+func TestCompareProjects(t *testing.T) {
+	assert := assert.New(t)
+
+	old := newProjectsResultList(map[string][]string{
+		"site-a": {"adminer.php"},
+		"site-b": {"adminer.php"},
+	})
+	new := newProjectsResultList(map[string][]string{
+		"site-a": {"adminer.php", "phpinfo.php"},
+		"site-b": {"phpinfo.php"},
+	})
+	diffs := Compare(old, new)
+	if assert.Len(diffs, 2) {
+		assert.Equal("site-a", diffs[0].Project)
+		assert.Len(diffs[0].Added, 1)
+		assert.Empty(diffs[0].Resolved)
+		assert.Len(diffs[0].Unchanged, 1)
+
+		// The breach added to site-b exists in site-a, and the one resolved in
+		// site-b remains in site-a.
+		assert.Equal("site-b", diffs[1].Project)
+		assert.Equal("phpinfo.php", result.BreachGetValue(diffs[1].Added[0]))
+		assert.Equal("adminer.php", result.BreachGetValue(diffs[1].Resolved[0]))
+		assert.Empty(diffs[1].Unchanged)
+	}
+}
+
+func TestResultFileLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	labels, err := ResultFileLabels([]string{"site-a.json", "out/site-b.json"})
+	assert.NoError(err)
+	assert.Equal([]string{"site-a", "site-b"}, labels)
+
+	labels, err = ResultFileLabels([]string{"ci/a/results.json", "./ci/b/results.json"})
+	assert.NoError(err)
+	assert.Equal([]string{"ci/a/results", "ci/b/results"}, labels)
+
+	_, err = ResultFileLabels([]string{"results.json", "./results.json"})
+	assert.EqualError(err, "result files have duplicate labels: results.json, ./results.json")
+}
+
+func TestCompare(t *testing.T) {
+	assert := assert.New(t)
+
+	old := newResultList("normal", "adminer.php", "phpinfo.php")
+	new := newResultList("normal", "phpinfo.php", "bigdump.php")
+	diffs := Compare(old, new)
+	assert.Len(diffs, 1)
+	assert.Equal("illegal files", diffs[0].CheckName)
+	assert.Equal("bigdump.php", result.BreachGetValue(diffs[0].Added[0]))
+	assert.Equal("adminer.php", result.BreachGetValue(diffs[0].Resolved[0]))
+	assert.Equal("phpinfo.php", result.BreachGetValue(diffs[0].Unchanged[0]))
+
+	assert.Len(AddedAtOrAbove(diffs, config.NormalSeverity), 1)
+	assert.Empty(AddedAtOrAbove(diffs, config.HighSeverity))
+
+	var buf bytes.Buffer
+	Display(&buf, diffs)
+	assert.Equal(`1 added, 1 resolved, 1 unchanged breach(es)
+
+  ### illegal files (file)
+     + [normal] bigdump.php
+     - [normal] adminer.php
+     = 1 unchanged
+`, buf.String())
+}
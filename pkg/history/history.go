@@ -0,0 +1,185 @@
+// Package history records the results of runs to a local directory, in order
+// to report on their trend over time.
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// DefaultDir is the directory in which the runs are recorded by default,
+// relative to the current directory.
+const DefaultDir = ".shipshape/history"
+
+// Record is a run as stored in the history. The summary fields are kept
+// alongside the full result list so that they can be read back without
+// decoding the breaches.
+type Record struct {
+	ID                    string          `json:"id"`
+	Timestamp             time.Time       `json:"timestamp"`
+	Project               string          `json:"project"`
+	ConfigHash            string          `json:"config-hash"`
+	TotalChecks           uint32          `json:"total-checks"`
+	TotalBreaches         uint32          `json:"total-breaches"`
+	BreachCountBySeverity map[string]int  `json:"breach-count-by-severity"`
+	BreachCountByType     map[string]int  `json:"breach-count-by-type"`
+	Breaches              []BreachRecord  `json:"breaches"`
+	ResultList            json.RawMessage `json:"result-list"`
+}
+
+// BreachRecord identifies a non-remediated breach of a run.
+type BreachRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	// The project of the breach's result, in batch and merged runs.
+	Project   string `json:"project,omitempty"`
+	CheckType string `json:"check-type"`
+	CheckName string `json:"check-name"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message"`
+}
+
+// NewRecord creates the history record of a run; its breaches and breach
+// counts leave out the successfully remediated breaches.
+func NewRecord(rl *result.ResultList, project string, configHash string) (Record, error) {
+	timestamp := rl.StartTime
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	rec := Record{
+		Timestamp:             timestamp,
+		Project:               project,
+		ConfigHash:            configHash,
+		TotalChecks:           rl.TotalChecks,
+		BreachCountBySeverity: map[string]int{},
+		BreachCountByType:     map[string]int{},
+		Breaches:              []BreachRecord{},
+	}
+	for _, r := range rl.Results {
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			rec.TotalBreaches++
+			rec.BreachCountBySeverity[r.Severity]++
+			rec.BreachCountByType[r.CheckType]++
+			rec.Breaches = append(rec.Breaches, BreachRecord{
+				Fingerprint: result.ProjectBreachFingerprint(r.Project, b),
+				Project:     r.Project,
+				CheckType:   b.GetCheckType(),
+				CheckName:   b.GetCheckName(),
+				Severity:    b.GetSeverity(),
+				Message:     b.String(),
+			})
+		}
+	}
+
+	data, err := json.Marshal(rl)
+	if err != nil {
+		return Record{}, fmt.Errorf("unable to convert result to json: %w", err)
+	}
+	rec.ResultList = data
+	// Runs started in the same millisecond, e.g. for several projects, are
+	// told apart by a hash of their content.
+	sum := sha256.Sum256([]byte(project + "\x00" + configHash + "\x00" + string(data)))
+	rec.ID = timestamp.UTC().Format("20060102-150405.000") + "-" + hex.EncodeToString(sum[:4])
+	return rec, nil
+}
+
+// Save writes the record to the directory, creating it if required, and
+// returns the path of the file.
+func Save(dir string, rec Record) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, rec.ID+".json")
+	return path, os.WriteFile(path, data, 0644)
+}
+
+// Load reads all the records from the directory, oldest first.
+func Load(dir string) ([]Record, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	records := []Record{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		rec := Record{}
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("invalid history record '%s': %w", f, err)
+		}
+		records = append(records, rec)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// Trend is a run along with the breaches introduced and resolved since the
+// previous run of the same project.
+type Trend struct {
+	Record
+	// Whether there is a previous run to compare with.
+	HasPrevious bool
+	New         []BreachRecord
+	Resolved    []BreachRecord
+}
+
+// Trends compares each record with the previous one of the same project;
+// records must be sorted, oldest first.
+func Trends(records []Record) []Trend {
+	trends := []Trend{}
+	previous := map[string]Record{}
+	for _, rec := range records {
+		t := Trend{Record: rec, New: []BreachRecord{}, Resolved: []BreachRecord{}}
+		if prev, ok := previous[rec.Project]; ok {
+			t.HasPrevious = true
+			t.New = breachesNotIn(rec.Breaches, prev.Breaches)
+			t.Resolved = breachesNotIn(prev.Breaches, rec.Breaches)
+		}
+		previous[rec.Project] = rec
+		trends = append(trends, t)
+	}
+	return trends
+}
+
+// breachesNotIn returns the breaches from a whose fingerprint is not in b.
+func breachesNotIn(a []BreachRecord, b []BreachRecord) []BreachRecord {
+	fingerprints := map[string]bool{}
+	for _, br := range b {
+		fingerprints[br.Fingerprint] = true
+	}
+	breaches := []BreachRecord{}
+	for _, br := range a {
+		if !fingerprints[br.Fingerprint] {
+			breaches = append(breaches, br)
+		}
+	}
+	return breaches
+}
+
+func (br BreachRecord) String() string {
+	name := br.CheckName
+	if br.Project != "" {
+		name += " (" + br.Project + ")"
+	}
+	return fmt.Sprintf("[%s] %s: %s", br.Severity, name,
+		strings.ReplaceAll(br.Message, "\n", " "))
+}
@@ -0,0 +1,169 @@
+package history_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/history"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newResultList(start time.Time, values ...string) result.ResultList {
+	rl := result.NewResultList(false)
+	rl.StartTime = start
+	r := result.Result{Name: "illegal files", CheckType: "file", Severity: "high"}
+	for _, v := range values {
+		b := &result.ValueBreach{Value: v}
+		b.SetCommonValues("file", "illegal files", "high")
+		r.Breaches = append(r.Breaches, b)
+	}
+	rl.IncrChecks("file", 1)
+	rl.AddResult(r)
+	return rl
+}
+
+func TestNewRecord(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	rl := newResultList(start, "adminer.php", "phpinfo.php")
+	rl.Results[0].Breaches[1].SetRemediation(result.RemediationStatusSuccess, "deleted")
+	rec, err := NewRecord(&rl, "/app", "abc")
+	assert.NoError(err)
+	assert.Regexp(`^20230501-103000\.000-[0-9a-f]{8}$`, rec.ID)
+	assert.Equal(start, rec.Timestamp)
+	assert.Equal("/app", rec.Project)
+	assert.Equal("abc", rec.ConfigHash)
+	assert.Equal(uint32(1), rec.TotalChecks)
+	assert.Equal(uint32(1), rec.TotalBreaches)
+	assert.Equal(map[string]int{"high": 1}, rec.BreachCountBySeverity)
+	assert.Equal(map[string]int{"file": 1}, rec.BreachCountByType)
+	assert.Equal([]BreachRecord{{
+		Fingerprint: result.BreachFingerprint(rl.Results[0].Breaches[0]),
+		CheckType:   "file",
+		CheckName:   "illegal files",
+		Severity:    "high",
+		Message:     "adminer.php",
+	}}, rec.Breaches)
+	assert.Contains(string(rec.ResultList), `"value":"adminer.php"`)
+}
+
+func TestSaveLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	records, err := Load(dir)
+	assert.NoError(err)
+	assert.Empty(records)
+
+	start := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	for _, s := range []time.Time{start.Add(time.Hour), start} {
+		rl := newResultList(s, "adminer.php")
+		rec, err := NewRecord(&rl, "/app", "abc")
+		assert.NoError(err)
+		_, err = Save(dir, rec)
+		assert.NoError(err)
+	}
+
+	records, err = Load(dir)
+	assert.NoError(err)
+	assert.Len(records, 2)
+	assert.Regexp(`^20230501-103000\.000-`, records[0].ID)
+	assert.Regexp(`^20230501-113000\.000-`, records[1].ID)
+	assert.Len(records[1].Breaches, 1)
+}
+
+func TestSaveSameTimestamp(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	start := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	for _, project := range []string{"/app/site-a", "/app/site-b"} {
+		rl := newResultList(start, "adminer.php")
+		rec, err := NewRecord(&rl, project, "abc")
+		assert.NoError(err)
+		_, err = Save(dir, rec)
+		assert.NoError(err)
+	}
+
+	records, err := Load(dir)
+	assert.NoError(err)
+	assert.Len(records, 2)
+	assert.NotEqual(records[0].ID, records[1].ID)
+}
+
+func TestTrendsProjects(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	records := []Record{}
+	for i, breaches := range []map[string]string{
+		{"site-a": "adminer.php", "site-b": "adminer.php"},
+		{"site-a": "adminer.php", "site-b": "phpinfo.php"},
+	} {
+		rl := result.NewResultList(false)
+		rl.StartTime = start.Add(time.Duration(i) * time.Hour)
+		for _, project := range []string{"site-a", "site-b"} {
+			b := &result.ValueBreach{Value: breaches[project]}
+			b.SetCommonValues("file", "illegal files", "high")
+			rl.AddResult(result.Result{Name: "illegal files", CheckType: "file",
+				Severity: "high", Project: project, Breaches: []result.Breach{b}})
+		}
+		rec, err := NewRecord(&rl, "/app", "abc")
+		assert.NoError(err)
+		records = append(records, rec)
+	}
+
+	trends := Trends(records)
+	// adminer.php is only resolved in site-b.
+	if assert.Len(trends[1].New, 1) && assert.Len(trends[1].Resolved, 1) {
+		assert.Equal("[high] illegal files (site-b): phpinfo.php", trends[1].New[0].String())
+		assert.Equal("[high] illegal files (site-b): adminer.php", trends[1].Resolved[0].String())
+	}
+}
+
+func TestDisplayTrend(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2023, 5, 1, 10, 30, 0, 0, time.UTC)
+	records := []Record{}
+	for i, values := range [][]string{
+		{"adminer.php", "phpinfo.php"},
+		{"phpinfo.php", "bigdump.php"},
+	} {
+		rl := newResultList(start.Add(time.Duration(i)*time.Hour), values...)
+		rec, err := NewRecord(&rl, "/app", "abc")
+		assert.NoError(err)
+		records = append(records, rec)
+	}
+
+	trends := Trends(records)
+	assert.False(trends[0].HasPrevious)
+	assert.True(trends[1].HasPrevious)
+	assert.Equal("bigdump.php", trends[1].New[0].Message)
+	assert.Equal("adminer.php", trends[1].Resolved[0].Message)
+
+	var buf bytes.Buffer
+	DisplayTrend(&buf, records)
+	assert.Equal(`ID                             PROJECT   BREACHES   CRITICAL   HIGH   NORMAL   LOW   NEW   RESOLVED
+`+records[0].ID+`   /app      2          0          2      0        0     -     -
+`+records[1].ID+`   /app      2          0          2      0        0     +1    -1
+
+ID                             FILE
+`+records[0].ID+`   2
+`+records[1].ID+`   2
+
+Since the previous run of /app:
+  New breaches: 1
+     -- [high] illegal files: bigdump.php
+  Resolved breaches: 1
+     -- [high] illegal files: adminer.php
+`, buf.String())
+
+	buf.Reset()
+	DisplayTrend(&buf, nil)
+	assert.Equal("No run recorded.\n", buf.String())
+}
@@ -0,0 +1,101 @@
+package history
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+)
+
+// DisplayList outputs the recorded runs, oldest first.
+func DisplayList(w io.Writer, records []Record) {
+	if len(records) == 0 {
+		fmt.Fprint(w, "No run recorded.\n")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprint(tw, "ID\tDATE\tPROJECT\tCONFIG\tCHECKS\tBREACHES\n")
+	for _, rec := range records {
+		hash := rec.ConfigHash
+		if len(hash) > 12 {
+			hash = hash[:12]
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\n", rec.ID,
+			rec.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			rec.Project, hash, rec.TotalChecks, rec.TotalBreaches)
+	}
+	tw.Flush()
+}
+
+// DisplayTrend outputs the breach counts per severity and type of each run,
+// followed by the breaches introduced and resolved by the latest run.
+func DisplayTrend(w io.Writer, records []Record) {
+	if len(records) == 0 {
+		fmt.Fprint(w, "No run recorded.\n")
+		return
+	}
+	trends := Trends(records)
+
+	severities := []string{}
+	for i := len(config.Severities) - 1; i >= 0; i-- {
+		severities = append(severities, string(config.Severities[i]))
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "ID\tPROJECT\tBREACHES\t%s\tNEW\tRESOLVED\n",
+		strings.ToUpper(strings.Join(severities, "\t")))
+	for _, t := range trends {
+		fmt.Fprintf(tw, "%s\t%s\t%d", t.ID, t.Project, t.TotalBreaches)
+		for _, sv := range severities {
+			fmt.Fprintf(tw, "\t%d", t.BreachCountBySeverity[sv])
+		}
+		if t.HasPrevious {
+			fmt.Fprintf(tw, "\t+%d\t-%d\n", len(t.New), len(t.Resolved))
+		} else {
+			fmt.Fprint(tw, "\t-\t-\n")
+		}
+	}
+	tw.Flush()
+
+	types := map[string]bool{}
+	for _, t := range trends {
+		for ct := range t.BreachCountByType {
+			types[ct] = true
+		}
+	}
+	if len(types) > 0 {
+		sortedTypes := []string{}
+		for ct := range types {
+			sortedTypes = append(sortedTypes, ct)
+		}
+		sort.Strings(sortedTypes)
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		fmt.Fprintf(tw, "ID\t%s\n", strings.ToUpper(strings.Join(sortedTypes, "\t")))
+		for _, t := range trends {
+			fmt.Fprint(tw, t.ID)
+			for _, ct := range sortedTypes {
+				fmt.Fprintf(tw, "\t%d", t.BreachCountByType[ct])
+			}
+			fmt.Fprintln(tw)
+		}
+		tw.Flush()
+	}
+
+	latest := trends[len(trends)-1]
+	if !latest.HasPrevious {
+		return
+	}
+	fmt.Fprintf(w, "\nSince the previous run of %s:\n", latest.Project)
+	displayBreaches(w, "New breaches", latest.New)
+	displayBreaches(w, "Resolved breaches", latest.Resolved)
+}
+
+func displayBreaches(w io.Writer, title string, breaches []BreachRecord) {
+	fmt.Fprintf(w, "  %s: %d\n", title, len(breaches))
+	for _, br := range breaches {
+		fmt.Fprintf(w, "     -- %s\n", br)
+	}
+}
@@ -22,6 +22,7 @@ type FetchDataTest struct {
 	// Expected values after running the check.
 	ExpectPasses   []string
 	ExpectBreaches []result.Breach
+	ExpectErrors   []string
 	ExpectDataMap  map[string][]byte
 }
 
@@ -50,6 +51,12 @@ func TestFetchData(t *testing.T, ctest FetchDataTest) {
 		assert.Empty(r.Breaches)
 	}
 
+	if len(ctest.ExpectErrors) > 0 {
+		assert.ElementsMatch(ctest.ExpectErrors, r.Errors)
+	} else {
+		assert.Empty(r.Errors)
+	}
+
 	if ctest.ExpectDataMap != nil {
 		dataMap := reflect.ValueOf(ctest.Check).Elem().FieldByName("DataMap").Interface().(map[string][]byte)
 		assert.EqualValues(ctest.ExpectDataMap, dataMap)
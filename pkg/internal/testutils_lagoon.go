@@ -10,6 +10,7 @@ import (
 
 var MockLagoonNumCalls int
 var MockLagoonRequestBodies []string
+var MockLagoonFailAddFacts bool
 
 func MockLagoonServer() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -21,7 +22,13 @@ func MockLagoonServer() *httptest.Server {
 			fmt.Fprintf(w, "{\"data\":{\"environmentByKubernetesNamespaceName\":{\"id\": 50}}}")
 		} else if strings.Contains(string(reqBody), "deleteProblemsFromSource") { // Response for the deletion.
 			fmt.Fprintf(w, "{\"data\":{\"deleteProblemsFromSource\":\"success\"}}")
+		} else if strings.Contains(string(reqBody), "deleteFactsFromSource") {
+			fmt.Fprintf(w, "{\"data\":{\"deleteFactsFromSource\":\"success\"}}")
 		} else if strings.Contains(string(reqBody), "AddFactsByNameInput") { // Response for the add.
+			if MockLagoonFailAddFacts {
+				fmt.Fprintf(w, "{\"errors\":[{\"message\":\"unable to add facts\"}]}")
+				return
+			}
 			fmt.Fprintf(w, "{}")
 		} else {
 			panic(string(reqBody))
@@ -32,6 +39,7 @@ func MockLagoonServer() *httptest.Server {
 func MockLagoonReset() {
 	MockLagoonNumCalls = 0
 	MockLagoonRequestBodies = []string{}
+	MockLagoonFailAddFacts = false
 }
 
 type MockInsightsRemoteTestState struct {
@@ -28,6 +28,7 @@ type RunCheckTest struct {
 	ExpectPasses []string
 	ExpectNoFail bool
 	ExpectFails  []result.Breach
+	ExpectErrors []string
 }
 
 // TestRunCheck can be used to run test scenarios in test tables.
@@ -75,4 +76,10 @@ func TestRunCheck(t *testing.T, ctest RunCheckTest) {
 			r.Breaches,
 			"Expected fails: %#v \nGot %#v", ctest.ExpectFails, r.Breaches)
 	}
+
+	if len(ctest.ExpectErrors) > 0 {
+		assert.ElementsMatch(ctest.ExpectErrors, r.Errors)
+	} else {
+		assert.Empty(r.Errors)
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
@@ -18,6 +19,8 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// Fact is informational data about the environment, such as the PHP version
+// or the base images in use.
 type Fact struct {
 	Name        string `json:"name"`
 	Value       string `json:"value"`
@@ -26,6 +29,14 @@ type Fact struct {
 	Category    string `json:"category"`
 }
 
+// AddFactsByNameInput is the input of the addFactsByName mutation; its name
+// is used as the mutation variable's type.
+type AddFactsByNameInput struct {
+	Project     string `json:"project"`
+	Environment string `json:"environment"`
+	Facts       []Fact `json:"facts"`
+}
+
 type ProblemSeverityRating string
 
 type Problem struct {
@@ -50,6 +61,8 @@ var ApiBaseUrl string
 var ApiToken string
 var PushProblemsToInsightRemote bool
 var LagoonInsightsRemoteEndpoint string
+var PushFacts bool
+var LagoonInsightsRemoteFactsEndpoint string
 
 var project string
 var environment string
@@ -147,8 +160,8 @@ func ProcessResultList(w *bufio.Writer, list result.ResultList) error {
 			Severity:          SeverityTranslation(config.Severity(r.Severity)),
 			SeverityScore:     0,
 			AssociatedPackage: "",
-			Description:       "",
-			Links:             "",
+			Description:       ProblemDescription(r),
+			Links:             strings.Join(r.References, ", "),
 		})
 	}
 
@@ -168,8 +181,33 @@ func ProcessResultList(w *bufio.Writer, list result.ResultList) error {
 	return nil
 }
 
+// ProblemDescription combines the check's description, rationale and
+// remediation guidance; references are pushed as the problem's links.
+func ProblemDescription(r result.Result) string {
+	parts := []string{}
+	if r.Description != "" {
+		parts = append(parts, r.Description)
+	}
+	if r.Rationale != "" {
+		parts = append(parts, "Rationale: "+r.Rationale)
+	}
+	if r.RemediationGuidance != "" {
+		parts = append(parts, "Remediation: "+r.RemediationGuidance)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
 func ProblemsToInsightsRemote(problems []Problem, serviceEndpoint string, bearerToken string) error {
-	bodyString, err := json.Marshal(problems)
+	return postToInsightsRemote(problems, "problems", serviceEndpoint, bearerToken)
+}
+
+// FactsToInsightsRemote pushes the facts to the Insights Remote service.
+func FactsToInsightsRemote(facts []Fact, serviceEndpoint string, bearerToken string) error {
+	return postToInsightsRemote(facts, "facts", serviceEndpoint, bearerToken)
+}
+
+func postToInsightsRemote(payload any, kind string, serviceEndpoint string, bearerToken string) error {
+	bodyString, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
@@ -185,7 +223,7 @@ func ProblemsToInsightsRemote(problems []Problem, serviceEndpoint string, bearer
 	}
 
 	if response.StatusCode != 200 {
-		return fmt.Errorf("there was an error sending the problems to '%s' : %s", serviceEndpoint, response.Body)
+		return fmt.Errorf("there was an error sending the %s to '%s' : %s", kind, serviceEndpoint, response.Body)
 	}
 	return nil
 }
@@ -206,6 +244,132 @@ func DeleteProblems() error {
 	return Client.Mutate(context.Background(), &m, variables)
 }
 
+// ProcessFacts replaces the facts previously pushed by Shipshape with those
+// of the result list, via Insights Remote when running in the cluster or the
+// addFactsByName mutation otherwise.
+func ProcessFacts(w *bufio.Writer, list result.ResultList) error {
+	facts := FactsFromResultList(list)
+
+	InitClient()
+	// Lagoon does not accept a fact whose name already exists for the
+	// environment, so the previous facts have to be deleted before pushing.
+	if err := DeleteFacts(); err != nil {
+		return fmt.Errorf("unable to delete previous facts: %w", err)
+	}
+	if len(facts) == 0 {
+		fmt.Fprintln(w, "no fact to push to Lagoon; only deleted previous facts")
+		w.Flush()
+		return nil
+	}
+
+	bearerToken, err := GetBearerTokenFromDisk(DefaultLagoonInsightsTokenLocation)
+	if err == nil {
+		if err := FactsToInsightsRemote(facts, LagoonInsightsRemoteFactsEndpoint, bearerToken); err != nil {
+			return fmt.Errorf("previous facts were deleted but new facts could not be pushed: %w", err)
+		}
+		fmt.Fprintln(w, "successfully pushed facts to Lagoon Remote")
+	} else {
+		if err := AddFacts(facts); err != nil {
+			return fmt.Errorf("previous facts were deleted but new facts could not be pushed: %w", err)
+		}
+		fmt.Fprintln(w, "successfully pushed facts to Lagoon")
+	}
+	w.Flush()
+	return nil
+}
+
+// FactsFromResultList converts the results into facts: one per check for
+// its status and passes, and one per informational value recorded by the
+// check.
+func FactsFromResultList(list result.ResultList) []Fact {
+	facts := []Fact{}
+	for _, r := range list.Results {
+		if r.Status == result.Skip {
+			continue
+		}
+
+		// Results of different projects share check names, so the project is
+		// part of the fact name to keep it unique.
+		name := r.Name
+		if r.Project != "" {
+			name = "[" + r.Project + "] " + name
+		}
+
+		value := string(r.Status)
+		if len(r.Passes) > 0 {
+			value += ": " + strings.Join(r.Passes, "; ")
+		}
+		facts = append(facts, Fact{
+			Name:        name,
+			Value:       TruncateFactValue(value),
+			Source:      SourceName,
+			Description: r.Description,
+			Category:    r.CheckType,
+		})
+
+		factNames := make([]string, 0, len(r.Facts))
+		for fn := range r.Facts {
+			factNames = append(factNames, fn)
+		}
+		sort.Strings(factNames)
+		for _, fn := range factNames {
+			facts = append(facts, Fact{
+				Name:        name + ": " + fn,
+				Value:       TruncateFactValue(r.Facts[fn]),
+				Source:      SourceName,
+				Description: r.Description,
+				Category:    r.CheckType,
+			})
+		}
+	}
+	return facts
+}
+
+// TruncateFactValue shortens the value to FactMaxValueLength characters,
+// which is the maximum accepted by Lagoon.
+func TruncateFactValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= FactMaxValueLength {
+		return value
+	}
+	return string(runes[:FactMaxValueLength-3]) + "..."
+}
+
+// DeleteFacts removes the facts previously pushed by Shipshape for the
+// environment.
+func DeleteFacts() error {
+	envId, err := GetEnvironmentIdFromEnvVars()
+	if err != nil {
+		return err
+	}
+	var m struct {
+		DeleteFactsFromSource string `graphql:"deleteFactsFromSource(input: {environment: $envId, source: $sourceName})"`
+	}
+	variables := map[string]interface{}{
+		"envId":      envId,
+		"sourceName": SourceName,
+	}
+	return Client.Mutate(context.Background(), &m, variables)
+}
+
+// AddFacts pushes the facts for the environment through the Lagoon API.
+func AddFacts(facts []Fact) error {
+	MustHaveEnvVars()
+	var m struct {
+		AddFactsByName []struct {
+			Id int
+		} `graphql:"addFactsByName(input: $input)"`
+	}
+	variables := map[string]interface{}{
+		"input": AddFactsByNameInput{
+			Project:     project,
+			Environment: environment,
+			Facts:       facts,
+		},
+	}
+	return Client.Mutate(context.Background(), &m, variables)
+}
+
 // SeverityTranslation will convert a ShipShape severity rating to a Lagoon rating
 func SeverityTranslation(ssSeverity config.Severity) ProblemSeverityRating {
 	// Currently supported severity levels in Lagoon
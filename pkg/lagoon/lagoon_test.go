@@ -1,15 +1,18 @@
 package lagoon_test
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/internal"
 	"github.com/salsadigitalauorg/shipshape/pkg/lagoon"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
 
 	"github.com/hasura/go-graphql-client"
 	"github.com/sirupsen/logrus"
@@ -118,6 +121,228 @@ func TestDeleteProblems(t *testing.T) {
 		"\"Shipshape\"}}\n", internal.MockLagoonRequestBodies[1])
 }
 
+func TestDeleteFacts(t *testing.T) {
+	assert := assert.New(t)
+
+	svr := internal.MockLagoonServer()
+	lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+	origOutput := logrus.StandardLogger().Out
+	os.Setenv("LAGOON_PROJECT", "foo")
+	os.Setenv("LAGOON_ENVIRONMENT", "bar")
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	defer func() {
+		svr.Close()
+		internal.MockLagoonReset()
+		lagoon.Client = nil
+		os.Unsetenv("LAGOON_PROJECT")
+		os.Unsetenv("LAGOON_ENVIRONMENT")
+		logrus.SetOutput(origOutput)
+	}()
+
+	err := lagoon.DeleteFacts()
+	assert.NoError(err)
+	assert.Equal(2, internal.MockLagoonNumCalls)
+	assert.Equal("{\"query\":\"mutation ($envId:Int!$sourceName:String!)"+
+		"{deleteFactsFromSource(input: {environment: $envId, source: "+
+		"$sourceName})}\",\"variables\":{\"envId\":50,\"sourceName\":"+
+		"\"Shipshape\"}}\n", internal.MockLagoonRequestBodies[1])
+}
+
+func TestAddFacts(t *testing.T) {
+	assert := assert.New(t)
+
+	svr := internal.MockLagoonServer()
+	lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+	os.Setenv("LAGOON_PROJECT", "foo")
+	os.Setenv("LAGOON_ENVIRONMENT", "bar")
+	defer func() {
+		svr.Close()
+		internal.MockLagoonReset()
+		lagoon.Client = nil
+		os.Unsetenv("LAGOON_PROJECT")
+		os.Unsetenv("LAGOON_ENVIRONMENT")
+	}()
+
+	err := lagoon.AddFacts([]lagoon.Fact{{
+		Name:     "PHPStan: errors",
+		Value:    "0",
+		Source:   "Shipshape",
+		Category: "phpstan",
+	}})
+	assert.NoError(err)
+	assert.Equal(1, internal.MockLagoonNumCalls)
+	assert.Equal("{\"query\":\"mutation ($input:AddFactsByNameInput!)"+
+		"{addFactsByName(input: $input){id}}\",\"variables\":{\"input\":"+
+		"{\"project\":\"foo\",\"environment\":\"bar\",\"facts\":[{"+
+		"\"name\":\"PHPStan: errors\",\"value\":\"0\",\"source\":\"Shipshape\","+
+		"\"description\":\"\",\"category\":\"phpstan\"}]}}}\n",
+		internal.MockLagoonRequestBodies[0])
+}
+
+func TestFactsFromResultList(t *testing.T) {
+	assert := assert.New(t)
+
+	long := strings.Repeat("a", lagoon.FactMaxValueLength+10)
+	facts := lagoon.FactsFromResultList(result.ResultList{Results: []result.Result{
+		{
+			Name:        "PHPStan",
+			CheckType:   "phpstan",
+			Description: "Static analysis of custom code",
+			Status:      result.Pass,
+			Passes:      []string{"no error found"},
+			Facts:       map[string]string{"file errors": "0", "errors": "0"},
+		},
+		{
+			Name:      "Illegal files",
+			CheckType: "file",
+			Status:    result.Fail,
+		},
+		{
+			Name:      "Skipped",
+			CheckType: "file",
+			Status:    result.Skip,
+		},
+		{
+			Name:      "Modules",
+			CheckType: "drupal-file-module",
+			Status:    result.Pass,
+			Facts:     map[string]string{"checked enabled modules": long},
+		},
+	}})
+	assert.Equal([]lagoon.Fact{
+		{
+			Name:        "PHPStan",
+			Value:       "Pass: no error found",
+			Source:      "Shipshape",
+			Description: "Static analysis of custom code",
+			Category:    "phpstan",
+		},
+		{
+			Name:        "PHPStan: errors",
+			Value:       "0",
+			Source:      "Shipshape",
+			Description: "Static analysis of custom code",
+			Category:    "phpstan",
+		},
+		{
+			Name:        "PHPStan: file errors",
+			Value:       "0",
+			Source:      "Shipshape",
+			Description: "Static analysis of custom code",
+			Category:    "phpstan",
+		},
+		{
+			Name:     "Illegal files",
+			Value:    "Fail",
+			Source:   "Shipshape",
+			Category: "file",
+		},
+		{
+			Name:     "Modules",
+			Value:    "Pass",
+			Source:   "Shipshape",
+			Category: "drupal-file-module",
+		},
+		{
+			Name:     "Modules: checked enabled modules",
+			Value:    long[:lagoon.FactMaxValueLength-3] + "...",
+			Source:   "Shipshape",
+			Category: "drupal-file-module",
+		},
+	}, facts)
+}
+
+func TestFactsFromResultListProjects(t *testing.T) {
+	assert := assert.New(t)
+
+	facts := lagoon.FactsFromResultList(result.ResultList{Results: []result.Result{
+		{Name: "Modules", Project: "site1", CheckType: "drupal-file-module", Status: result.Pass,
+			Facts: map[string]string{"checked enabled modules": "views"}},
+		{Name: "Modules", Project: "site2", CheckType: "drupal-file-module", Status: result.Pass},
+	}})
+	names := []string{}
+	for _, f := range facts {
+		names = append(names, f.Name)
+	}
+	assert.Equal([]string{
+		"[site1] Modules",
+		"[site1] Modules: checked enabled modules",
+		"[site2] Modules",
+	}, names)
+}
+
+func TestTruncateFactValue(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("short", lagoon.TruncateFactValue("short"))
+	exact := strings.Repeat("é", lagoon.FactMaxValueLength)
+	assert.Equal(exact, lagoon.TruncateFactValue(exact))
+	truncated := lagoon.TruncateFactValue(exact + "é")
+	assert.Equal(lagoon.FactMaxValueLength, len([]rune(truncated)))
+	assert.True(strings.HasSuffix(truncated, "é..."))
+}
+
+func TestProcessFacts(t *testing.T) {
+	assert := assert.New(t)
+
+	svr := internal.MockLagoonServer()
+	lagoon.Client = graphql.NewClient(svr.URL, http.DefaultClient)
+	os.Setenv("LAGOON_PROJECT", "foo")
+	os.Setenv("LAGOON_ENVIRONMENT", "bar")
+	defer func() {
+		svr.Close()
+		internal.MockLagoonReset()
+		lagoon.Client = nil
+		os.Unsetenv("LAGOON_PROJECT")
+		os.Unsetenv("LAGOON_ENVIRONMENT")
+	}()
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := lagoon.ProcessFacts(w, result.ResultList{Results: []result.Result{
+		{Name: "PHPStan", CheckType: "phpstan", Status: result.Pass},
+	}})
+	assert.NoError(err)
+	assert.Equal("successfully pushed facts to Lagoon\n", buf.String())
+	// The previous facts are deleted before the new ones are added.
+	assert.Equal(3, internal.MockLagoonNumCalls)
+	assert.Contains(internal.MockLagoonRequestBodies[1], "deleteFactsFromSource")
+	assert.Contains(internal.MockLagoonRequestBodies[2], "addFactsByName")
+
+	internal.MockLagoonReset()
+	buf.Reset()
+	err = lagoon.ProcessFacts(w, result.ResultList{})
+	assert.NoError(err)
+	assert.Equal("no fact to push to Lagoon; only deleted previous facts\n", buf.String())
+	assert.Equal(2, internal.MockLagoonNumCalls)
+
+	// A failed push reports that the previous facts are already gone.
+	internal.MockLagoonReset()
+	internal.MockLagoonFailAddFacts = true
+	buf.Reset()
+	err = lagoon.ProcessFacts(w, result.ResultList{Results: []result.Result{
+		{Name: "PHPStan", CheckType: "phpstan", Status: result.Pass},
+	}})
+	assert.ErrorContains(err, "previous facts were deleted but new facts could not be pushed")
+	assert.Empty(buf.String())
+}
+
+func Test_FactsToInsightsRemote(t *testing.T) {
+	assert := assert.New(t)
+
+	state := internal.MockInsightsRemoteTestState{}
+	serv := internal.MockRemoteInsightsServer(&state)
+	defer serv.Close()
+
+	facts := []lagoon.Fact{{Name: "PHPStan", Value: "Pass", Source: "Shipshape", Category: "phpstan"}}
+	assert.NoError(lagoon.FactsToInsightsRemote(facts, serv.URL+"/facts", "bearertoken"))
+	assert.Equal("/facts", state.LastCallEndpoint)
+	var sent []lagoon.Fact
+	assert.NoError(json.Unmarshal([]byte(state.LastCallBody), &sent))
+	assert.Equal(facts, sent)
+}
+
 func Test_GetBearerTokenFromDisk(t *testing.T) {
 	type args struct {
 		tokenLocation string
@@ -194,3 +419,18 @@ func Test_ProblemsToInsightsRemote(t *testing.T) {
 		})
 	}
 }
+
+func TestProblemDescription(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("", lagoon.ProblemDescription(result.Result{}))
+	assert.Equal("Checks admin permissions.\n\n"+
+		"Rationale: Admins can take over the site.\n\n"+
+		"Remediation: Remove the permission from the role.",
+		lagoon.ProblemDescription(result.Result{
+			Description:         "Checks admin permissions.",
+			Rationale:           "Admins can take over the site.",
+			References:          []string{"https://example.com"},
+			RemediationGuidance: "Remove the permission from the role.",
+		}))
+}
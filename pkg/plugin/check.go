@@ -0,0 +1,135 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// PluginCheck is a check whose logic is implemented by a plugin.
+type PluginCheck struct {
+	config.CheckBase `yaml:",inline"`
+	// The check-specific fields, sent to the plugin as its config.
+	Config map[string]any `yaml:",inline"`
+
+	plugin   config.Plugin
+	response *Response
+}
+
+// Init implementation for the plugin check.
+func (c *PluginCheck) Init(ct config.CheckType) {
+	c.CheckBase.Init(ct)
+	c.RequiresDb = c.plugin.RequiresDb
+}
+
+// Merge implementation for the plugin check.
+func (c *PluginCheck) Merge(mergeCheck config.Check) error {
+	pluginMergeCheck := mergeCheck.(*PluginCheck)
+	if err := c.CheckBase.Merge(&pluginMergeCheck.CheckBase); err != nil {
+		return err
+	}
+	for k, v := range pluginMergeCheck.Config {
+		if c.Config == nil {
+			c.Config = map[string]any{}
+		}
+		c.Config[k] = v
+	}
+	return nil
+}
+
+func (c *PluginCheck) request(action string) Request {
+	return Request{
+		Action:     action,
+		CheckType:  string(c.GetType()),
+		CheckName:  c.GetName(),
+		Severity:   string(c.GetSeverity()),
+		ProjectDir: c.GetProjectDir(),
+		Config:     c.Config,
+	}
+}
+
+// FetchData runs the plugin to get the check's result.
+func (c *PluginCheck) FetchData() {
+	resp, err := Run(c.plugin, c.request(ActionCheck))
+	if err != nil {
+		c.AddError(fmt.Sprintf("plugin '%s' failed: %s", c.plugin.Command, err))
+		return
+	}
+	c.response = resp
+	c.DataMap = map[string][]byte{}
+}
+
+// HasData is overridden so that a plugin which failed to run is reported as
+// an error rather than a breach.
+func (c *PluginCheck) HasData(failCheck bool) bool {
+	if c.response == nil && len(c.Result.Errors) > 0 {
+		return false
+	}
+	return c.CheckBase.HasData(failCheck)
+}
+
+// RunCheck adds the plugin's response to the result.
+func (c *PluginCheck) RunCheck() {
+	if c.response == nil {
+		return
+	}
+	for _, raw := range c.response.Breaches {
+		b, err := result.UnmarshalBreach(raw)
+		if err != nil {
+			c.AddError(fmt.Sprintf("invalid breach from plugin: %s", err))
+			continue
+		}
+		c.AddBreach(b)
+	}
+	for _, p := range c.response.Passes {
+		c.AddPass(p)
+	}
+	for _, w := range c.response.Warnings {
+		c.AddWarning(w)
+	}
+	for _, e := range c.response.Errors {
+		c.AddError(e)
+	}
+	for name, v := range c.response.Facts {
+		c.AddFact(name, v)
+	}
+	if len(c.Result.Breaches) == 0 && len(c.Result.Errors) == 0 {
+		c.Result.Status = result.Pass
+	}
+}
+
+// SupportsRemediation implements config.RemediationSupporter.
+func (c *PluginCheck) SupportsRemediation() bool {
+	return c.plugin.SupportsRemediation
+}
+
+// Remediate sends the breaches to the plugin if it supports remediation.
+func (c *PluginCheck) Remediate() {
+	if !c.plugin.SupportsRemediation {
+		c.CheckBase.Remediate()
+		return
+	}
+
+	req := c.request(ActionRemediate)
+	req.Breaches = c.Result.Breaches
+	resp, err := Run(c.plugin, req)
+	for i, b := range c.Result.Breaches {
+		if err != nil {
+			b.SetRemediation(result.RemediationStatusFailed, fmt.Sprintf(
+				"plugin '%s' failed: %s", c.plugin.Command, err))
+			continue
+		}
+		if i >= len(resp.Remediations) {
+			b.SetRemediation(result.RemediationStatusNoSupport, "")
+			continue
+		}
+		rem := resp.Remediations[i]
+		if len(rem.Messages) == 0 {
+			b.SetRemediation(rem.Status, "")
+		}
+		for _, msg := range rem.Messages {
+			b.SetRemediation(rem.Status, msg)
+		}
+	}
+}
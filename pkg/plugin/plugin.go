@@ -0,0 +1,152 @@
+// Package plugin provides check types implemented by external executables,
+// which communicate with shipshape by reading a JSON request on their stdin
+// and writing a JSON response on their stdout.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// Actions requested from a plugin.
+const (
+	ActionCheck     = "check"
+	ActionRemediate = "remediate"
+)
+
+// Request is written to the plugin's stdin.
+type Request struct {
+	Action     string         `json:"action"`
+	CheckType  string         `json:"check-type"`
+	CheckName  string         `json:"check-name"`
+	Severity   string         `json:"severity"`
+	ProjectDir string         `json:"project-dir"`
+	Config     map[string]any `json:"config"`
+	// The breaches to remediate, for the remediate action.
+	Breaches []result.Breach `json:"breaches,omitempty"`
+}
+
+// Response is read from the plugin's stdout.
+type Response struct {
+	Passes   []string `json:"passes,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+	// Informational data about the project, keyed by name.
+	Facts map[string]string `json:"facts,omitempty"`
+	// Breaches are decoded based on their breach-type, e.g. value,
+	// key-value or key-values.
+	Breaches []json.RawMessage `json:"breaches,omitempty"`
+	// The outcome of the remediate action, for each breach of the request
+	// in the same order.
+	Remediations []Remediation `json:"remediations,omitempty"`
+}
+
+// Remediation is the outcome of a breach's remediation.
+type Remediation struct {
+	Status   result.RemediationStatus `json:"status"`
+	Messages []string                 `json:"messages,omitempty"`
+}
+
+// Register adds the plugin's check type to the registry. Registering the
+// same plugin again is a no-op, so that the config can be parsed more than
+// once, but a check type cannot be replaced by a different plugin.
+func Register(r *config.Registry, p config.Plugin) error {
+	if p.Type == "" {
+		return fmt.Errorf("plugin type required")
+	}
+	if p.Command == "" {
+		return fmt.Errorf("command required for plugin '%s'", p.Type)
+	}
+	// The plugin runs in the project directory, so a relative path to the
+	// executable needs resolving first.
+	if strings.ContainsRune(p.Command, filepath.Separator) && !filepath.IsAbs(p.Command) {
+		abs, err := filepath.Abs(p.Command)
+		if err != nil {
+			return err
+		}
+		p.Command = abs
+	}
+
+	return r.RegisterPlugin(p, func() config.Check { return &PluginCheck{plugin: p} }, config.Meta{
+		Description: p.Description,
+		Fields:      []string{},
+	})
+}
+
+// RegisterDir registers each executable file of the directory as a plugin,
+// whose check type is the file name without its extension.
+func RegisterDir(r *config.Registry, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		name := e.Name()
+		err = Register(r, config.Plugin{
+			Type:    config.CheckType(strings.TrimSuffix(name, filepath.Ext(name))),
+			Command: filepath.Join(dir, name),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run sends the request to the plugin and decodes its response; the plugin
+// is killed if it runs longer than its timeout.
+func Run(p config.Plugin, req Request) (*Response, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	cmd.Dir = req.ProjectDir
+	cmd.Stdin = bytes.NewReader(data)
+	stderr := bytes.Buffer{}
+	cmd.Stderr = &stderr
+	// Don't wait indefinitely for child processes holding the outputs once
+	// the plugin has been killed.
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %s", p.Timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	resp := &Response{}
+	if err := json.Unmarshal(out, resp); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,199 @@
+package plugin_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	. "github.com/salsadigitalauorg/shipshape/pkg/plugin"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writePlugin creates a plugin which saves its request next to it and
+// writes the response provided.
+func writePlugin(t *testing.T, dir string, name string, response string) string {
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\ncat > \"" + path + ".request\"\ncat <<'EOF'\n" + response + "\nEOF\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readRequest(t *testing.T, path string) map[string]any {
+	data, err := os.ReadFile(path + ".request")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := map[string]any{}
+	if err := json.Unmarshal(data, &req); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func parseCheck(t *testing.T, r *config.Registry, data string) *PluginCheck {
+	cfg, err := config.ParseConfig([]byte(data), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ct, checks := range cfg.Checks {
+		c := checks[0].(*PluginCheck)
+		c.Init(ct)
+		return c
+	}
+	t.Fatal("no check parsed")
+	return nil
+}
+
+func TestPluginCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path := writePlugin(t, dir, "security", `{
+  "passes": ["composer.lock found"],
+  "warnings": ["no lock file for npm"],
+  "facts": {"packages": "42"},
+  "breaches": [
+    {"breach-type": "key-value", "key": "guzzlehttp/guzzle", "value": "7.4.0", "expected-value": "7.4.5"}
+  ]
+}`)
+
+	r := config.NewRegistry()
+	assert.NoError(Register(r, config.Plugin{Type: "security", Command: path, RequiresDb: true}))
+	c := parseCheck(t, r, `
+checks:
+  security:
+    - name: Vulnerable packages
+      severity: high
+      lock-file: composer.lock
+`)
+	assert.True(c.RequiresDatabase())
+	meta, _ := r.Meta("security")
+	assert.True(meta.RequiresDb)
+	assert.False(meta.SupportsRemediation)
+	c.SetProjectDir(dir)
+	c.FetchData()
+	assert.True(c.HasData(true))
+	c.RunCheck()
+
+	assert.Equal([]string{"composer.lock found"}, c.Result.Passes)
+	assert.Equal([]string{"no lock file for npm"}, c.Result.Warnings)
+	assert.Equal(map[string]string{"packages": "42"}, c.Result.Facts)
+	assert.Equal([]result.Breach{&result.KeyValueBreach{
+		BreachType:    result.BreachTypeKeyValue,
+		CheckType:     "security",
+		CheckName:     "Vulnerable packages",
+		Severity:      "high",
+		Key:           "guzzlehttp/guzzle",
+		Value:         "7.4.0",
+		ExpectedValue: "7.4.5",
+	}}, c.Result.Breaches)
+
+	assert.Equal(map[string]any{
+		"action":      "check",
+		"check-type":  "security",
+		"check-name":  "Vulnerable packages",
+		"severity":    "high",
+		"project-dir": dir,
+		"config":      map[string]any{"lock-file": "composer.lock"},
+	}, readRequest(t, path))
+}
+
+func TestPluginCheckFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken")
+	assert.NoError(os.WriteFile(path, []byte("#!/bin/sh\necho 'php not found' >&2\nexit 3\n"), 0755))
+
+	r := config.NewRegistry()
+	assert.NoError(Register(r, config.Plugin{Type: "broken", Command: path}))
+	c := parseCheck(t, r, "checks:\n  broken:\n    - name: Broken\n")
+	c.FetchData()
+	assert.False(c.HasData(true))
+	c.RunCheck()
+	assert.Empty(c.Result.Breaches)
+	assert.Equal([]string{"plugin '" + path + "' failed: exit status 3: php not found"}, c.Result.Errors)
+}
+
+func TestPluginCheckTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "slow")
+	assert.NoError(os.WriteFile(path, []byte("#!/bin/sh\nsleep 5\n"), 0755))
+
+	r := config.NewRegistry()
+	assert.NoError(Register(r, config.Plugin{Type: "slow", Command: path, Timeout: 50 * time.Millisecond}))
+	c := parseCheck(t, r, "checks:\n  slow:\n    - name: Slow\n")
+	c.FetchData()
+	assert.False(c.HasData(true))
+	assert.Equal([]string{"plugin '" + path + "' failed: timed out after 50ms"}, c.Result.Errors)
+}
+
+func TestPluginCheckRemediate(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path := writePlugin(t, dir, "fixer", `{"remediations": [{"status": "success", "messages": ["removed"]}]}`)
+
+	r := config.NewRegistry()
+	assert.NoError(Register(r, config.Plugin{Type: "fixer", Command: path, SupportsRemediation: true}))
+	meta, _ := r.Meta("fixer")
+	assert.True(meta.SupportsRemediation)
+	c := parseCheck(t, r, "checks:\n  fixer:\n    - name: Fixer\n")
+	c.AddBreach(&result.ValueBreach{Value: "adminer.php"})
+	c.AddBreach(&result.ValueBreach{Value: "phpmyadmin.php"})
+	c.Remediate()
+
+	assert.Equal(result.Remediation{
+		Status:   result.RemediationStatusSuccess,
+		Messages: []string{"removed"},
+	}, *c.Result.Breaches[0].GetRemediation())
+	assert.Equal(result.RemediationStatusNoSupport, c.Result.Breaches[1].GetRemediation().Status)
+
+	req := readRequest(t, path)
+	assert.Equal("remediate", req["action"])
+	assert.Len(req["breaches"], 2)
+}
+
+func TestRegister(t *testing.T) {
+	assert := assert.New(t)
+
+	r := config.NewRegistry()
+	assert.NoError(r.Register("file", func() config.Check { return &PluginCheck{} }, config.Meta{}))
+
+	assert.EqualError(Register(r, config.Plugin{Command: "foo"}), "plugin type required")
+	assert.EqualError(Register(r, config.Plugin{Type: "foo"}), "command required for plugin 'foo'")
+	assert.EqualError(Register(r, config.Plugin{Type: "file", Command: "foo"}),
+		"check type 'file' is already registered")
+
+	assert.NoError(Register(r, config.Plugin{Type: "foo", Command: "foo", Description: "Foo"}))
+	assert.NoError(Register(r, config.Plugin{Type: "foo", Command: "foo", Description: "Foo"}))
+	assert.EqualError(Register(r, config.Plugin{Type: "foo", Command: "bar"}),
+		"plugin 'foo' is already registered with a different command")
+	meta, _ := r.Meta("foo")
+	assert.Equal("Foo", meta.Description)
+
+	// Registrations are kept per registry.
+	assert.NoError(Register(config.NewRegistry(), config.Plugin{Type: "foo", Command: "bar"}))
+}
+
+func TestRegisterDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "security.py", "{}")
+	assert.NoError(os.WriteFile(filepath.Join(dir, "README.md"), []byte{}, 0644))
+	assert.NoError(os.Mkdir(filepath.Join(dir, "lib"), 0755))
+
+	r := config.NewRegistry()
+	assert.NoError(RegisterDir(r, dir))
+	assert.Equal([]config.CheckType{"security"}, r.Types())
+}
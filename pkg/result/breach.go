@@ -30,18 +30,27 @@ const (
 
 //go:generate go run ../../cmd/gen.go breach-type --type=Value,KeyValue,KeyValues
 
+// Location points to where a breach was found, when the check is able to
+// determine it. File is relative to the project directory where possible.
+type Location struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
 // Simple breach with no key.
 // Example:
 //
 //	"file foo.ext not found": file is the ValueLabel, foo.ext is the Value
 type ValueBreach struct {
 	BreachType    `json:"breach-type"`
-	CheckType     string `json:"check-type"`
-	CheckName     string `json:"check-name"`
-	Severity      string `json:"severity"`
-	ValueLabel    string `json:"value-label,omitempty"`
-	Value         string `json:"value"`
-	ExpectedValue string `json:"expected-value,omitempty"`
+	CheckType     string    `json:"check-type"`
+	CheckName     string    `json:"check-name"`
+	Severity      string    `json:"severity"`
+	ValueLabel    string    `json:"value-label,omitempty"`
+	Value         string    `json:"value"`
+	ExpectedValue string    `json:"expected-value,omitempty"`
+	Location      *Location `json:"location,omitempty"`
 	Remediation   `json:"remediation,omitempty"`
 }
 
@@ -62,14 +71,15 @@ func (b ValueBreach) String() string {
 //	  - wordpress is the Value
 type KeyValueBreach struct {
 	BreachType    `json:"breach-type"`
-	CheckType     string `json:"check-type"`
-	CheckName     string `json:"check-name"`
-	Severity      string `json:"severity"`
-	KeyLabel      string `json:"key-label,omitempty"`
-	Key           string `json:"key,omitempty"`
-	ValueLabel    string `json:"value-label,omitempty"`
-	Value         string `json:"value"`
-	ExpectedValue string `json:"expected-value,omitempty"`
+	CheckType     string    `json:"check-type"`
+	CheckName     string    `json:"check-name"`
+	Severity      string    `json:"severity"`
+	KeyLabel      string    `json:"key-label,omitempty"`
+	Key           string    `json:"key,omitempty"`
+	ValueLabel    string    `json:"value-label,omitempty"`
+	Value         string    `json:"value"`
+	ExpectedValue string    `json:"expected-value,omitempty"`
+	Location      *Location `json:"location,omitempty"`
 	Remediation   `json:"remediation,omitempty"`
 }
 
@@ -90,13 +100,14 @@ func (b KeyValueBreach) String() string {
 //	  - [administer site configuration, import configuration] are the Values
 type KeyValuesBreach struct {
 	BreachType  `json:"breach-type"`
-	CheckType   string   `json:"check-type"`
-	CheckName   string   `json:"check-name"`
-	Severity    string   `json:"severity"`
-	KeyLabel    string   `json:"key-label,omitempty"`
-	Key         string   `json:"key,omitempty"`
-	ValueLabel  string   `json:"value-label,omitempty"`
-	Values      []string `json:"values"`
+	CheckType   string    `json:"check-type"`
+	CheckName   string    `json:"check-name"`
+	Severity    string    `json:"severity"`
+	KeyLabel    string    `json:"key-label,omitempty"`
+	Key         string    `json:"key,omitempty"`
+	ValueLabel  string    `json:"value-label,omitempty"`
+	Values      []string  `json:"values"`
+	Location    *Location `json:"location,omitempty"`
 	Remediation `json:"remediation,omitempty"`
 }
 
@@ -161,3 +172,14 @@ func BreachGetExpectedValue(bIfc Breach) string {
 	}
 	return ""
 }
+
+func BreachGetLocation(bIfc Breach) *Location {
+	if b, ok := bIfc.(*ValueBreach); ok {
+		return b.Location
+	} else if b, ok := bIfc.(*KeyValueBreach); ok {
+		return b.Location
+	} else if b, ok := bIfc.(*KeyValuesBreach); ok {
+		return b.Location
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// BreachFingerprint returns a stable identifier for a breach, derived from its
+// check and content. Line numbers are left out so that the fingerprint
+// survives unrelated changes to the file in which the breach was found.
+func BreachFingerprint(b Breach) string {
+	values := append([]string{}, BreachGetValues(b)...)
+	sort.Strings(values)
+
+	file := ""
+	if loc := BreachGetLocation(b); loc != nil {
+		file = loc.File
+	}
+
+	parts := []string{
+		b.GetCheckType(),
+		b.GetCheckName(),
+		string(b.GetType()),
+		BreachGetKeyLabel(b),
+		BreachGetKey(b),
+		BreachGetValueLabel(b),
+		BreachGetValue(b),
+		strings.Join(values, "\x1f"),
+		file,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1e")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ProjectBreachFingerprint returns the fingerprint of a breach found in a
+// project, so that the same breach in different projects is told apart; it
+// is the breach's fingerprint when there is no project.
+func ProjectBreachFingerprint(project string, b Breach) string {
+	if project == "" {
+		return BreachFingerprint(b)
+	}
+	sum := sha256.Sum256([]byte(project + "\x1e" + BreachFingerprint(b)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,55 @@
+package result_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+func TestBreachFingerprint(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &KeyValuesBreach{
+		CheckType:  "phpstan",
+		CheckName:  "phpstan",
+		KeyLabel:   "role",
+		Key:        "editor",
+		ValueLabel: "permissions",
+		Values:     []string{"b", "a"},
+		Location:   &Location{File: "web/foo.php", Line: 3},
+	}
+	fp := BreachFingerprint(b)
+	assert.Len(fp, 64)
+
+	t.Run("stableAcrossLinesAndOrder", func(t *testing.T) {
+		moved := *b
+		moved.Values = []string{"a", "b"}
+		moved.Location = &Location{File: "web/foo.php", Line: 10}
+		assert.Equal(fp, BreachFingerprint(&moved))
+	})
+
+	t.Run("changesWithContent", func(t *testing.T) {
+		changed := *b
+		changed.Values = []string{"a", "c"}
+		assert.NotEqual(fp, BreachFingerprint(&changed))
+
+		otherFile := *b
+		otherFile.Location = &Location{File: "web/bar.php", Line: 3}
+		assert.NotEqual(fp, BreachFingerprint(&otherFile))
+
+		otherCheck := *b
+		otherCheck.CheckName = "phpstan-2"
+		assert.NotEqual(fp, BreachFingerprint(&otherCheck))
+	})
+}
+
+func TestProjectBreachFingerprint(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &ValueBreach{Value: "adminer.php"}
+	b.SetCommonValues("file", "illegal files", "high")
+	assert.Equal(BreachFingerprint(b), ProjectBreachFingerprint("", b))
+	assert.NotEqual(ProjectBreachFingerprint("site-a", b), ProjectBreachFingerprint("site-b", b))
+}
@@ -0,0 +1,69 @@
+package result
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PolicyEvaluation is the outcome of evaluating the fail policy against the
+// results, explaining why a run failed.
+type PolicyEvaluation struct {
+	FailSeverity string                 `json:"fail-severity"`
+	Failed       bool                   `json:"failed"`
+	Rules        []PolicyRuleEvaluation `json:"rules,omitempty"`
+	// The number of checks which encountered errors while executing; they
+	// fail the run unless allowed by the policy.
+	Errors        int  `json:"errors,omitempty"`
+	ErrorsAllowed bool `json:"errors-allowed,omitempty"`
+}
+
+// PolicyRuleEvaluation is the count of breaches of a severity, for all check
+// types or a single one when it has an override, against the maximum allowed.
+type PolicyRuleEvaluation struct {
+	CheckType   string `json:"check-type,omitempty"`
+	Severity    string `json:"severity"`
+	Breaches    int    `json:"breaches"`
+	MaxBreaches int    `json:"max-breaches"`
+	Failed      bool   `json:"failed"`
+}
+
+// String describes the rule's outcome.
+func (re PolicyRuleEvaluation) String() string {
+	s := fmt.Sprintf("%d %s breach(es), max allowed %d", re.Breaches, re.Severity, re.MaxBreaches)
+	if re.CheckType != "" {
+		s += " for check type " + re.CheckType
+	}
+	return s
+}
+
+// FailedRules returns the rules which caused the run to fail.
+func (pe *PolicyEvaluation) FailedRules() []PolicyRuleEvaluation {
+	rules := []PolicyRuleEvaluation{}
+	for _, re := range pe.Rules {
+		if re.Failed {
+			rules = append(rules, re)
+		}
+	}
+	return rules
+}
+
+// ErrorsFailed returns whether checks encountered errors which fail the run.
+func (pe *PolicyEvaluation) ErrorsFailed() bool {
+	return pe.Errors > 0 && !pe.ErrorsAllowed
+}
+
+// Summary describes the outcome of the evaluation in a single line.
+func (pe *PolicyEvaluation) Summary() string {
+	if !pe.Failed {
+		return fmt.Sprintf("Fail policy passed (fail severity: %s)", pe.FailSeverity)
+	}
+	reasons := []string{}
+	if n := len(pe.FailedRules()); n > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d rule(s) exceeded", n))
+	}
+	if pe.ErrorsFailed() {
+		reasons = append(reasons, fmt.Sprintf("%d check(s) with errors", pe.Errors))
+	}
+	return fmt.Sprintf("Fail policy failed (fail severity: %s); %s",
+		pe.FailSeverity, strings.Join(reasons, ", "))
+}
@@ -2,6 +2,8 @@ package result
 
 import (
 	"sort"
+	"strings"
+	"time"
 )
 
 type Status string
@@ -9,9 +11,13 @@ type Status string
 const (
 	Pass Status = "Pass"
 	Fail Status = "Fail"
+	Skip Status = "Skip"
 )
 
 // Result provides the structure for a Check's outcome.
+// Errors are problems encountered while executing the check, as opposed to
+// policy breaches; SkipReason explains why a check with the Skip status was
+// not run. Duration is serialised in nanoseconds.
 type Result struct {
 	Name              string            `json:"name"`
 	Severity          string            `json:"severity"`
@@ -19,8 +25,34 @@ type Result struct {
 	Passes            []string          `json:"passes"`
 	Breaches          []Breach          `json:"breaches"`
 	Warnings          []string          `json:"warnings"`
+	Errors            []string          `json:"errors,omitempty"`
 	Status            Status            `json:"status"`
+	SkipReason        string            `json:"skip-reason,omitempty"`
 	RemediationStatus RemediationStatus `json:"remediation-status"`
+	StartTime         time.Time         `json:"start-time"`
+	Duration          time.Duration     `json:"duration"`
+	Timings           Timings           `json:"timings"`
+	// Metadata of the check, explaining its breaches.
+	Description         string   `json:"description,omitempty"`
+	Rationale           string   `json:"rationale,omitempty"`
+	References          []string `json:"references,omitempty"`
+	RemediationGuidance string   `json:"remediation-guidance,omitempty"`
+	Controls            []string `json:"controls,omitempty"`
+	// The project or source the result belongs to, when results of several
+	// runs are combined.
+	Project string `json:"project,omitempty"`
+	// Informational data gathered by the check, such as versions or totals,
+	// keyed by name.
+	Facts map[string]string `json:"facts,omitempty"`
+}
+
+// Timings records the time spent in each phase of processing a check; phases
+// which were not run are left at zero.
+type Timings struct {
+	FetchData        time.Duration `json:"fetch-data"`
+	UnmarshalDataMap time.Duration `json:"unmarshal-data-map"`
+	RunCheck         time.Duration `json:"run-check"`
+	Remediate        time.Duration `json:"remediate"`
 }
 
 // Sort reorders the Passes & Failures in order to get consistent output.
@@ -42,6 +74,10 @@ func (r *Result) Sort() {
 			return r.Warnings[i] < r.Warnings[j]
 		})
 	}
+
+	if len(r.Errors) > 0 {
+		sort.Strings(r.Errors)
+	}
 }
 
 // RemediationsCount returns the number of unsupported, successful, failed and
@@ -91,13 +127,43 @@ func (r *Result) DetermineResultStatus(remediationPerformed bool) {
 		}
 		r.RemediationStatus = RemediationStatusSuccess
 		r.Status = Pass
+		if len(r.Errors) > 0 {
+			r.Status = Fail
+		}
 		return
 	}
 
 	// Overall status.
-	if len(r.Breaches) > 0 {
+	if len(r.Breaches) > 0 || len(r.Errors) > 0 {
 		r.Status = Fail
 		return
 	}
 	r.Status = Pass
 }
+
+// MetadataLines describes the check's metadata as a list of labelled lines,
+// for the outputs which cannot render them as separate fields.
+func (r *Result) MetadataLines() []string {
+	lines := []string{}
+	if r.Description != "" {
+		lines = append(lines, "Description: "+r.Description)
+	}
+	if r.Rationale != "" {
+		lines = append(lines, "Rationale: "+r.Rationale)
+	}
+	if r.RemediationGuidance != "" {
+		lines = append(lines, "Remediation: "+r.RemediationGuidance)
+	}
+	if len(r.References) > 0 {
+		lines = append(lines, "References: "+strings.Join(r.References, ", "))
+	}
+	return lines
+}
+
+// DisplayName is the name of the check, prefixed with the project if any.
+func (r Result) DisplayName() string {
+	if r.Project == "" {
+		return r.Name
+	}
+	return "[" + r.Project + "] " + r.Name
+}
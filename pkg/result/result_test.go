@@ -280,3 +280,36 @@ func TestResultDetermineResultStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestResultDetermineResultStatusErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Result{Errors: []string{"check panicked"}}
+	r.DetermineResultStatus(false)
+	assert.Equal(Fail, r.Status)
+
+	r = Result{Errors: []string{"check panicked"}}
+	r.DetermineResultStatus(true)
+	assert.Equal(Fail, r.Status)
+	assert.Equal(RemediationStatusSuccess, r.RemediationStatus)
+}
+
+func TestResultMetadataLines(t *testing.T) {
+	assert := assert.New(t)
+
+	r := Result{}
+	assert.Empty(r.MetadataLines())
+
+	r = Result{
+		Description:         "Checks admin permissions.",
+		Rationale:           "Admins can take over the site.",
+		References:          []string{"https://example.com/a", "https://example.com/b"},
+		RemediationGuidance: "Remove the permission from the role.",
+	}
+	assert.Equal([]string{
+		"Description: Checks admin permissions.",
+		"Rationale: Admins can take over the site.",
+		"Remediation: Remove the permission from the role.",
+		"References: https://example.com/a, https://example.com/b",
+	}, r.MetadataLines())
+}
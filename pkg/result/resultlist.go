@@ -4,6 +4,8 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 // ResultList is a wrapper around a list of results, providing some useful
@@ -17,10 +19,26 @@ type ResultList struct {
 	BreachCountByType     map[string]int    `json:"breach-count-by-type"`
 	BreachCountBySeverity map[string]int    `json:"breach-count-by-severity"`
 	Results               []Result          `json:"results"`
+	// When the run started, and its overall wall time.
+	StartTime time.Time     `json:"start-time"`
+	Duration  time.Duration `json:"duration"`
+	// Outcome of the fail policy, determining the exit code.
+	Policy *PolicyEvaluation `json:"policy,omitempty"`
+	// Totals for each project when results of several projects were merged.
+	Projects []ProjectSummary `json:"projects,omitempty"`
+
+	// Makes map mutations concurrency-safe; it is shared by copies of the
+	// list, and created on first use for lists not made by NewResultList.
+	lock *sync.RWMutex
 }
 
-// Use locks to make map mutations concurrency-safe.
-var lock = sync.RWMutex{}
+// ProjectSummary holds the totals of a single project in a merged list.
+type ProjectSummary struct {
+	Project       string `json:"project"`
+	TotalChecks   uint32 `json:"total-checks"`
+	TotalBreaches uint32 `json:"total-breaches"`
+	Status        Status `json:"status"`
+}
 
 func NewResultList(remediate bool) ResultList {
 	rl := ResultList{
@@ -29,27 +47,49 @@ func NewResultList(remediate bool) ResultList {
 		CheckCountByType:      map[string]int{},
 		BreachCountByType:     map[string]int{},
 		BreachCountBySeverity: map[string]int{},
+		lock:                  &sync.RWMutex{},
 	}
 	return rl
 }
 
+// mutex returns the lock of the list, creating it if required.
+func (rl *ResultList) mutex() *sync.RWMutex {
+	ptr := (*unsafe.Pointer)(unsafe.Pointer(&rl.lock))
+	if lock := atomic.LoadPointer(ptr); lock != nil {
+		return (*sync.RWMutex)(lock)
+	}
+	atomic.CompareAndSwapPointer(ptr, nil, unsafe.Pointer(&sync.RWMutex{}))
+	return (*sync.RWMutex)(atomic.LoadPointer(ptr))
+}
+
 // IncrChecks increments the total checks count & checks count by type.
 func (rl *ResultList) IncrChecks(ct string, incr int) {
 	atomic.AddUint32(&rl.TotalChecks, uint32(incr))
 
+	lock := rl.mutex()
 	lock.Lock()
 	defer lock.Unlock()
+	if rl.CheckCountByType == nil {
+		rl.CheckCountByType = map[string]int{}
+	}
 	rl.CheckCountByType[ct] = rl.CheckCountByType[ct] + incr
 }
 
 // AddResult safely appends a check's result to the list.
 func (rl *ResultList) AddResult(r Result) {
+	lock := rl.mutex()
 	lock.Lock()
 	defer lock.Unlock()
 	rl.Results = append(rl.Results, r)
 
 	breachesIncr := len(r.Breaches)
 	atomic.AddUint32(&rl.TotalBreaches, uint32(breachesIncr))
+	if rl.BreachCountByType == nil {
+		rl.BreachCountByType = map[string]int{}
+	}
+	if rl.BreachCountBySeverity == nil {
+		rl.BreachCountBySeverity = map[string]int{}
+	}
 	rl.BreachCountByType[r.CheckType] = rl.BreachCountByType[r.CheckType] + breachesIncr
 	rl.BreachCountBySeverity[r.Severity] = rl.BreachCountBySeverity[r.Severity] + breachesIncr
 }
@@ -133,9 +173,60 @@ func (rl *ResultList) GetBreachesBySeverity(s string) []Breach {
 	return breaches
 }
 
-// Sort reorders the results by name.
+// Sort reorders the results by project, then name.
 func (rl *ResultList) Sort() {
-	sort.Slice(rl.Results, func(i int, j int) bool {
+	sort.SliceStable(rl.Results, func(i int, j int) bool {
+		if rl.Results[i].Project != rl.Results[j].Project {
+			return rl.Results[i].Project < rl.Results[j].Project
+		}
 		return rl.Results[i].Name < rl.Results[j].Name
 	})
 }
+
+// Merge combines result lists into a new one, recomputing the totals. The
+// results which do not belong to a project yet are labelled with the label
+// of their list.
+func Merge(labels []string, lists []ResultList) ResultList {
+	merged := NewResultList(false)
+	var end time.Time
+	for i, rl := range lists {
+		merged.RemediationPerformed = merged.RemediationPerformed || rl.RemediationPerformed
+		for ct, count := range rl.CheckCountByType {
+			merged.IncrChecks(ct, count)
+		}
+		if len(rl.CheckCountByType) == 0 {
+			atomic.AddUint32(&merged.TotalChecks, rl.TotalChecks)
+		}
+		if len(rl.Projects) > 0 {
+			merged.Projects = append(merged.Projects, rl.Projects...)
+		} else if i < len(labels) {
+			merged.Projects = append(merged.Projects, ProjectSummary{
+				Project:       labels[i],
+				TotalChecks:   rl.TotalChecks,
+				TotalBreaches: rl.TotalBreaches,
+				Status:        rl.Status(),
+			})
+		}
+		for _, r := range rl.Results {
+			if r.Project == "" && i < len(labels) {
+				r.Project = labels[i]
+			}
+			merged.AddResult(r)
+		}
+
+		if !rl.StartTime.IsZero() {
+			if merged.StartTime.IsZero() || rl.StartTime.Before(merged.StartTime) {
+				merged.StartTime = rl.StartTime
+			}
+			if rlEnd := rl.StartTime.Add(rl.Duration); rlEnd.After(end) {
+				end = rlEnd
+			}
+		}
+	}
+	if !merged.StartTime.IsZero() {
+		merged.Duration = end.Sub(merged.StartTime)
+	}
+	merged.Sort()
+	merged.RemediationTotalsCount()
+	return merged
+}
@@ -3,6 +3,7 @@ package result_test
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	. "github.com/salsadigitalauorg/shipshape/pkg/result"
@@ -67,6 +68,25 @@ func TestResultListIncrChecks(t *testing.T) {
 	assert.Equal(105, rl.CheckCountByType[string(testCheck2Type)])
 }
 
+func TestResultListZeroValue(t *testing.T) {
+	assert := assert.New(t)
+
+	rl := ResultList{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rl.IncrChecks(string(testCheckType), 1)
+			rl.AddResult(Result{CheckType: string(testCheckType), Breaches: []Breach{&ValueBreach{}}})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(10, rl.CheckCountByType[string(testCheckType)])
+	assert.Equal(10, rl.BreachCountByType[string(testCheckType)])
+	assert.Len(rl.Results, 10)
+}
+
 func TestResultListAddResult(t *testing.T) {
 	assert := assert.New(t)
 
@@ -395,3 +415,53 @@ func TestResultListSort(t *testing.T) {
 		{Name: "zcheck"},
 	}, rl.Results)
 }
+
+func TestMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	b1 := &ValueBreach{Value: "adminer.php"}
+	b1.SetCommonValues("file", "illegal files", "high")
+	b2 := &ValueBreach{Value: "devel"}
+	b2.SetCommonValues("yaml", "modules", "normal")
+	b2.SetRemediation(RemediationStatusSuccess, "uninstalled")
+
+	rl1 := NewResultList(false)
+	rl1.StartTime = start
+	rl1.Duration = time.Minute
+	rl1.IncrChecks("file", 1)
+	rl1.AddResult(Result{Name: "illegal files", CheckType: "file", Severity: "high", Breaches: []Breach{b1}})
+
+	rl2 := NewResultList(true)
+	rl2.StartTime = start.Add(time.Hour)
+	rl2.Duration = time.Minute
+	rl2.IncrChecks("file", 1)
+	rl2.IncrChecks("yaml", 1)
+	rl2.AddResult(Result{Name: "illegal files", CheckType: "file", Severity: "high"})
+	rl2.AddResult(Result{Name: "modules", CheckType: "yaml", Severity: "normal", Project: "site-b-prod", Breaches: []Breach{b2}})
+
+	merged := Merge([]string{"site-a", "site-b"}, []ResultList{rl1, rl2})
+	assert.True(merged.RemediationPerformed)
+	assert.Equal(uint32(3), merged.TotalChecks)
+	assert.Equal(uint32(2), merged.TotalBreaches)
+	assert.Equal(map[string]int{"file": 2, "yaml": 1}, merged.CheckCountByType)
+	assert.Equal(map[string]int{"file": 1, "yaml": 1}, merged.BreachCountByType)
+	assert.Equal(map[string]int{"high": 1, "normal": 1}, merged.BreachCountBySeverity)
+	assert.Equal(uint32(1), merged.RemediationTotals["successful"])
+	assert.Equal(start, merged.StartTime)
+	assert.Equal(time.Hour+time.Minute, merged.Duration)
+	assert.Equal([]ProjectSummary{
+		{Project: "site-a", TotalChecks: 1, TotalBreaches: 1, Status: Pass},
+		{Project: "site-b", TotalChecks: 2, TotalBreaches: 1, Status: Pass},
+	}, merged.Projects)
+
+	names := []string{}
+	for _, r := range merged.Results {
+		names = append(names, r.DisplayName())
+	}
+	assert.Equal([]string{
+		"[site-a] illegal files",
+		"[site-b] illegal files",
+		"[site-b-prod] modules",
+	}, names)
+}
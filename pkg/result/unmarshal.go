@@ -0,0 +1,62 @@
+package result
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// UnmarshalBreach decodes a breach, whose type is determined by its
+// breach-type field; the breach types are registered in breachFactories by
+// the breach-type generator.
+func UnmarshalBreach(data []byte) (Breach, error) {
+	bt := struct {
+		BreachType BreachType `json:"breach-type"`
+	}{}
+	if err := json.Unmarshal(data, &bt); err != nil {
+		return nil, err
+	}
+	factory, ok := breachFactories[bt.BreachType]
+	if !ok {
+		return nil, fmt.Errorf("unknown breach type '%s'", bt.BreachType)
+	}
+	b := factory()
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// UnmarshalJSON decodes a result, including its breaches which cannot be
+// decoded as-is since Breach is an interface.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	type resultAlias Result
+	aux := struct {
+		*resultAlias
+		Breaches []json.RawMessage `json:"breaches"`
+	}{resultAlias: (*resultAlias)(r)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.Breaches = nil
+	for _, raw := range aux.Breaches {
+		b, err := UnmarshalBreach(raw)
+		if err != nil {
+			return err
+		}
+		r.Breaches = append(r.Breaches, b)
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a result list and sets up its lock, so that results
+// can be added to it.
+func (rl *ResultList) UnmarshalJSON(data []byte) error {
+	type resultListAlias ResultList
+	if err := json.Unmarshal(data, (*resultListAlias)(rl)); err != nil {
+		return err
+	}
+	rl.lock = &sync.RWMutex{}
+	return nil
+}
@@ -0,0 +1,45 @@
+package result_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/salsadigitalauorg/shipshape/pkg/result"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("roundTrip", func(t *testing.T) {
+		vb := &ValueBreach{Value: "adminer.php", Location: &Location{File: "web/adminer.php"}}
+		vb.SetCommonValues("file", "illegal files", "high")
+		kvb := &KeyValueBreach{Key: "editor", Value: "administer modules"}
+		kvb.SetCommonValues("yaml", "permissions", "normal")
+		kvsb := &KeyValuesBreach{Key: "modules", Values: []string{"devel", "kint"}}
+		kvsb.SetCommonValues("yaml", "modules", "low")
+		kvsb.SetRemediation(RemediationStatusFailed, "could not uninstall")
+
+		rl := NewResultList(false)
+		rl.AddResult(Result{Name: "illegal files", Status: Fail, Breaches: []Breach{vb, kvb, kvsb}})
+		data, err := json.Marshal(rl)
+		assert.NoError(err)
+
+		decoded := ResultList{}
+		assert.NoError(json.Unmarshal(data, &decoded))
+		assert.Equal(rl, decoded)
+	})
+
+	t.Run("noBreach", func(t *testing.T) {
+		r := Result{}
+		assert.NoError(json.Unmarshal([]byte(`{"name":"a","breaches":null}`), &r))
+		assert.Equal(Result{Name: "a"}, r)
+	})
+
+	t.Run("unknownBreachType", func(t *testing.T) {
+		r := Result{}
+		err := json.Unmarshal([]byte(`{"name":"a","breaches":[{"breach-type":"foo"}]}`), &r)
+		assert.EqualError(err, "unknown breach type 'foo'")
+	})
+}
@@ -0,0 +1,101 @@
+package shipshape
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Engine runs checks using its own config, project directory, logger,
+// registry and results, unlike Init and RunChecks which use the package
+// state; several engines can therefore run concurrently in the same process.
+type Engine struct {
+	// The directory to check; it takes precedence over the config's and
+	// defaults to the current directory.
+	ProjectDir string
+	// The check types available to the config, to which its plugins are
+	// added; defaults to a copy of config.ChecksRegistry.
+	Registry *config.Registry
+	// Logger for the engine's own messages; defaults to the standard logger,
+	// whose level the engine leaves untouched.
+	Logger          log.FieldLogger
+	CheckTypesToRun []string
+	ExcludeDb       bool
+	Remediate       bool
+	// Environment for which to apply the severity overrides.
+	Environment string
+	// Overrides the fail severity of the config if set.
+	FailSeverity config.Severity
+
+	configData [][]byte
+	handlers   []EventHandler
+	lock       sync.RWMutex
+}
+
+// NewEngine creates an engine for the project directory, using the config
+// data provided; the data of multiple files is merged in order, as with
+// the --file flag.
+func NewEngine(projectDir string, configData ...[]byte) *Engine {
+	return &Engine{
+		ProjectDir: projectDir,
+		Registry:   config.ChecksRegistry.Clone(),
+		Logger:     log.StandardLogger(),
+		configData: configData,
+	}
+}
+
+// Config parses the engine's config data; each call creates new checks.
+func (e *Engine) Config() (config.Config, error) {
+	cfg, err := parseConfigData(e.configData, e.Registry, e.Logger)
+	if err != nil {
+		return cfg, err
+	}
+	setConfigDefaults(&cfg, e.ProjectDir)
+	if e.FailSeverity != "" {
+		cfg.FailSeverity = e.FailSeverity
+	}
+	return cfg, nil
+}
+
+// AddEventHandler registers a handler for the events of the engine's runs.
+func (e *Engine) AddEventHandler(h EventHandler) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.handlers = append(e.handlers, h)
+}
+
+func (e *Engine) publish(ev Event) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	for _, h := range e.handlers {
+		h(ev)
+	}
+}
+
+// Run creates the checks from the config and runs them against the project.
+// If the context is cancelled, the checks not started yet are reported as
+// skipped and the context's error is returned along with the results.
+func (e *Engine) Run(ctx context.Context) (*result.ResultList, error) {
+	cfg, err := e.Config()
+	if err != nil {
+		return nil, err
+	}
+	logger := e.Logger.WithField("project", cfg.ProjectDir)
+	err = prepareChecks(&cfg, cfg.ProjectDir, e.Environment, e.Remediate,
+		e.CheckTypesToRun, e.ExcludeDb, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := result.NewResultList(e.Remediate)
+	runChecks(ctx, &cfg, &rl, logger, e.publish)
+	return &rl, ctx.Err()
+}
@@ -0,0 +1,251 @@
+package shipshape_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	. "github.com/salsadigitalauorg/shipshape/pkg/shipshape"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type projectDirCheck struct {
+	config.CheckBase `yaml:",inline"`
+}
+
+func (c *projectDirCheck) RequiresData() bool { return false }
+
+func (c *projectDirCheck) RunCheck() {
+	c.AddPass(c.GetProjectDir())
+	c.Result.Status = result.Pass
+}
+
+func newTestEngine(projectDir string, configData string) *Engine {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	e := NewEngine(projectDir, []byte(configData))
+	e.Registry = config.NewRegistry()
+	e.Registry.Register("project-dir", func() config.Check { return &projectDirCheck{} }, config.Meta{})
+	e.Logger = logger
+	return e
+}
+
+func TestEngineRun(t *testing.T) {
+	assert := assert.New(t)
+
+	data := `
+checks:
+  project-dir:
+    - name: first
+    - name: second
+`
+	var wg sync.WaitGroup
+	lists := make([]*result.ResultList, 2)
+	for i, dir := range []string{"/site-a", "/site-b"} {
+		i, e := i, newTestEngine(dir, data)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rl, err := e.Run(context.Background())
+			assert.NoError(err)
+			lists[i] = rl
+		}()
+	}
+	wg.Wait()
+
+	for i, dir := range []string{"/site-a", "/site-b"} {
+		if !assert.NotNil(lists[i]) {
+			continue
+		}
+		assert.Equal(uint32(2), lists[i].TotalChecks)
+		assert.Equal(result.Pass, lists[i].Status())
+		assert.False(lists[i].Policy.Failed)
+		for _, r := range lists[i].Results {
+			assert.Equal([]string{dir}, r.Passes)
+		}
+	}
+}
+
+func TestEngineConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	e := newTestEngine("/site-a", "project-dir: /other\nchecks:\n  project-dir:\n    - name: first\n")
+	e.FailSeverity = config.CriticalSeverity
+	cfg, err := e.Config()
+	assert.NoError(err)
+	assert.Equal("/site-a", cfg.ProjectDir)
+	assert.Equal(config.CriticalSeverity, cfg.FailSeverity)
+	assert.Len(cfg.Checks["project-dir"], 1)
+
+	e = newTestEngine("", "checks:\n  project-dir: foo\n")
+	_, err = e.Run(context.Background())
+	assert.EqualError(err, "list required under check type 'project-dir', got !!str instead")
+}
+
+func TestEngineRunCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	e := newTestEngine("/site-a", "checks:\n  project-dir:\n    - name: first\n")
+	events := []Event{}
+	e.AddEventHandler(func(ev Event) { events = append(events, ev) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rl, err := e.Run(ctx)
+	assert.ErrorIs(err, context.Canceled)
+	if assert.Len(rl.Results, 1) {
+		assert.Equal(result.Skip, rl.Results[0].Status)
+		assert.Equal("run cancelled", rl.Results[0].SkipReason)
+	}
+	if assert.Len(events, 2) {
+		assert.Equal(EventRunStarted, events[0].Type)
+		assert.Equal(EventRunFinished, events[1].Type)
+	}
+}
+
+func TestEnginePlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho '{\"breaches\": [{\"breach-type\": \"value\", \"value\": \"debug enabled\"}]}'\n"
+	assert.NoError(os.WriteFile(filepath.Join(dir, "debug.sh"), []byte(script), 0755))
+
+	e := newTestEngine(dir, `
+plugins:
+  - type: debug
+    command: `+filepath.Join(dir, "debug.sh")+`
+checks:
+  debug:
+    - name: Debug mode
+`)
+	rl, err := e.Run(context.Background())
+	assert.NoError(err)
+	if assert.Len(rl.Results, 1) {
+		assert.Equal(result.Fail, rl.Results[0].Status)
+		assert.Equal("debug enabled", rl.Results[0].Breaches[0].String())
+	}
+
+	// Parsing the config again keeps the same plugin registered.
+	_, err = e.Run(context.Background())
+	assert.NoError(err)
+
+	e = newTestEngine(dir, "plugins-dir: "+filepath.Join(dir, "missing")+"\n")
+	_, err = e.Run(context.Background())
+	assert.ErrorContains(err, "could not register plugins")
+}
+
+func TestEnginesPlugins(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	for _, name := range []string{"a", "b"} {
+		script := "#!/bin/sh\necho '{\"passes\": [\"" + name + "\"]}'\n"
+		assert.NoError(os.WriteFile(filepath.Join(dir, name+".sh"), []byte(script), 0755))
+	}
+	configData := func(name string) []byte {
+		return []byte(`
+plugins:
+  - type: debug
+    command: ` + filepath.Join(dir, name+".sh") + `
+checks:
+  debug:
+    - name: Debug mode
+`)
+	}
+
+	// The same plugin type can have a different command in each engine.
+	for _, name := range []string{"a", "b"} {
+		e := NewEngine(dir, configData(name))
+		e.Logger = logrus.New()
+		e.Logger.(*logrus.Logger).SetOutput(io.Discard)
+		rl, err := e.Run(context.Background())
+		assert.NoError(err)
+		if assert.Len(rl.Results, 1) {
+			assert.Equal([]string{name}, rl.Results[0].Passes)
+		}
+	}
+
+	// Plugins of an engine are not available to others.
+	_, ok := NewEngine(dir).Registry.Get("debug")
+	assert.False(ok)
+}
+
+type resultsCountCheck struct {
+	config.CheckBase `yaml:",inline"`
+	Deps             []string `yaml:"deps"`
+	results          []result.Result
+}
+
+func (c *resultsCountCheck) ResultsDependencies() []string { return c.Deps }
+
+func (c *resultsCountCheck) SetResults(results []result.Result) { c.results = results }
+
+func (c *resultsCountCheck) RequiresData() bool { return false }
+
+func (c *resultsCountCheck) RunCheck() {
+	for _, r := range c.results {
+		c.AddPass(r.Name)
+	}
+	c.Result.Status = result.Pass
+}
+
+func TestEngineResultsConsumer(t *testing.T) {
+	assert := assert.New(t)
+
+	e := newTestEngine("/site-a", `
+checks:
+  project-dir:
+    - name: first
+    - name: second
+  results-count:
+    - name: count
+`)
+	e.Registry.Register("results-count", func() config.Check { return &resultsCountCheck{} }, config.Meta{})
+	rl, err := e.Run(context.Background())
+	assert.NoError(err)
+	assert.Len(rl.Results, 3)
+	for _, r := range rl.Results {
+		if r.Name == "count" {
+			assert.ElementsMatch([]string{"first", "second"}, r.Passes)
+		}
+	}
+}
+
+func TestEngineResultsConsumerDependencies(t *testing.T) {
+	assert := assert.New(t)
+
+	e := newTestEngine("/site-a", `
+checks:
+  project-dir:
+    - name: first
+  results-count:
+    - name: count-all
+      deps: [count-first]
+    - name: count-first
+    - name: loop-a
+      deps: [loop-b]
+    - name: loop-b
+      deps: [loop-a]
+`)
+	e.Registry.Register("results-count", func() config.Check { return &resultsCountCheck{} }, config.Meta{})
+	rl, err := e.Run(context.Background())
+	assert.NoError(err)
+	assert.Len(rl.Results, 5)
+	passes := map[string][]string{}
+	for _, r := range rl.Results {
+		passes[r.Name] = r.Passes
+	}
+	assert.ElementsMatch([]string{"first"}, passes["count-first"])
+	assert.ElementsMatch([]string{"first", "count-first"}, passes["count-all"])
+	// Circular references run once nothing else is pending, without
+	// each other's results.
+	assert.ElementsMatch([]string{"first", "count-first", "count-all"}, passes["loop-a"])
+	assert.ElementsMatch([]string{"first", "count-first", "count-all"}, passes["loop-b"])
+}
@@ -0,0 +1,95 @@
+package shipshape
+
+import (
+	"sync"
+	"time"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+type EventType string
+
+const (
+	EventRunStarted           EventType = "run-started"
+	EventCheckStarted         EventType = "check-started"
+	EventRemediationPerformed EventType = "remediation-performed"
+	EventCheckFinished        EventType = "check-finished"
+	EventRunFinished          EventType = "run-finished"
+)
+
+// Event is published at each step of the lifecycle of a run.
+type Event struct {
+	Type      EventType `json:"event"`
+	Time      time.Time `json:"time"`
+	CheckType string    `json:"check-type,omitempty"`
+	CheckName string    `json:"check-name,omitempty"`
+	// Project the event relates to, when running against several projects;
+	// the final run-finished event, totalling all the projects, has none.
+	Project string `json:"project,omitempty"`
+	// Result of the check, for check-finished events.
+	Result *result.Result `json:"result,omitempty"`
+	// Outcome of the remediation, for remediation-performed events.
+	RemediationStatus result.RemediationStatus `json:"remediation-status,omitempty"`
+	// Totals for run-started & run-finished events.
+	Totals *RunTotals `json:"totals,omitempty"`
+}
+
+// RunTotals summarises the state of a run.
+type RunTotals struct {
+	TotalChecks           uint32                   `json:"total-checks"`
+	TotalBreaches         uint32                   `json:"total-breaches"`
+	BreachCountByType     map[string]int           `json:"breach-count-by-type,omitempty"`
+	BreachCountBySeverity map[string]int           `json:"breach-count-by-severity,omitempty"`
+	RemediationTotals     map[string]uint32        `json:"remediation-totals,omitempty"`
+	Status                result.Status            `json:"status,omitempty"`
+	Duration              time.Duration            `json:"duration,omitempty"`
+	Policy                *result.PolicyEvaluation `json:"policy,omitempty"`
+}
+
+// EventHandler is called for every event published during a run; handlers
+// are called concurrently from the goroutines processing the checks.
+type EventHandler func(Event)
+
+var eventHandlers []EventHandler
+var eventHandlersLock = sync.RWMutex{}
+
+// AddEventHandler registers a handler to be notified of the run events.
+func AddEventHandler(h EventHandler) {
+	eventHandlersLock.Lock()
+	defer eventHandlersLock.Unlock()
+	eventHandlers = append(eventHandlers, h)
+}
+
+// ResetEventHandlers removes all the registered handlers.
+func ResetEventHandlers() {
+	eventHandlersLock.Lock()
+	defer eventHandlersLock.Unlock()
+	eventHandlers = nil
+}
+
+func publishEvent(e Event) {
+	eventHandlersLock.RLock()
+	defer eventHandlersLock.RUnlock()
+	if len(eventHandlers) == 0 {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for _, h := range eventHandlers {
+		h(e)
+	}
+}
+
+func newRunTotals(rl *result.ResultList) *RunTotals {
+	return &RunTotals{
+		TotalChecks:           rl.TotalChecks,
+		TotalBreaches:         rl.TotalBreaches,
+		BreachCountByType:     rl.BreachCountByType,
+		BreachCountBySeverity: rl.BreachCountBySeverity,
+		RemediationTotals:     rl.RemediationTotals,
+		Status:                rl.Status(),
+		Duration:              rl.Duration,
+		Policy:                rl.Policy,
+	}
+}
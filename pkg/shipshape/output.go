@@ -2,13 +2,160 @@ package shipshape
 
 import (
 	"bufio"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
 )
 
+// ParseOutputs converts a list of output specifications in the form
+// 'format[=file]' into a list of outputs; the template file is used for
+// the template format.
+func ParseOutputs(specs []string, templateFile string) ([]config.Output, error) {
+	outputs := []config.Output{}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		format, file, _ := strings.Cut(spec, "=")
+		outputs = append(outputs, config.Output{
+			Format: strings.TrimSpace(format),
+			File:   strings.TrimSpace(file),
+		})
+	}
+	SetOutputsTemplate(outputs, templateFile)
+	if err := ValidateOutputs(outputs); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// ValidateOutputs ensures the outputs use a supported format and that at most
+// one of them is written to stdout.
+func ValidateOutputs(outputs []config.Output) error {
+	stdoutCount := 0
+	for _, o := range outputs {
+		if !utils.StringSliceContains(OutputFormats, o.Format) {
+			return fmt.Errorf("invalid output format '%s'; needs to be one of: %s",
+				o.Format, strings.Join(OutputFormats, "|"))
+		}
+		if o.Format == "template" && o.Template == "" {
+			return fmt.Errorf("a template file is required for the template output")
+		}
+		if o.File == "" {
+			stdoutCount++
+		}
+	}
+	if stdoutCount > 1 {
+		return fmt.Errorf("only one output can be written to stdout; " +
+			"provide a file for the others, e.g. 'junit=junit.xml'")
+	}
+	return nil
+}
+
+// SetOutputsTemplate sets the template file on the template outputs which do
+// not already have one.
+func SetOutputsTemplate(outputs []config.Output, templateFile string) {
+	for i := range outputs {
+		if outputs[i].Format == "template" && outputs[i].Template == "" {
+			outputs[i].Template = templateFile
+		}
+	}
+}
+
+// SetOutputsLocationFile sets the file to which the CI outputs attribute the
+// breaches without a location to the first local config file, since that is
+// where the breached policy is defined.
+func SetOutputsLocationFile(outputs []config.Output, configFiles []string) {
+	file := ""
+	for _, f := range configFiles {
+		if !utils.StringIsUrl(f) {
+			file = f
+			break
+		}
+	}
+	for i := range outputs {
+		if outputs[i].LocationFile == "" {
+			outputs[i].LocationFile = file
+		}
+	}
+}
+
+// WriteOutputs renders the results for each output, writing them to their
+// file if provided, or to stdout otherwise.
+func WriteOutputs(stdout io.Writer, outputs []config.Output) error {
+	for _, o := range outputs {
+		if o.File == "" {
+			if err := RenderOutput(stdout, o); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(o.File), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(o.File)
+		if err != nil {
+			return err
+		}
+		err = RenderOutput(f, o)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderOutput writes the results to the writer in the output's format.
+func RenderOutput(w io.Writer, o config.Output) error {
+	switch o.Format {
+	case "json":
+		data, err := json.Marshal(RunResultList)
+		if err != nil {
+			return fmt.Errorf("unable to convert result to json: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	case "junit":
+		JUnit(bufio.NewWriter(w))
+	case "table":
+		TableDisplay(tabwriter.NewWriter(w, 0, 0, 3, ' ', 0))
+	case "simple":
+		SimpleDisplay(bufio.NewWriter(w))
+	case "markdown":
+		MarkdownDisplay(bufio.NewWriter(w))
+	case "html":
+		HTMLDisplay(bufio.NewWriter(w))
+	case "github":
+		GitHubDisplay(bufio.NewWriter(w), o.LocationFile)
+	case "gitlab-codequality":
+		GitLabCodeQualityDisplay(bufio.NewWriter(w), o.LocationFile)
+	case "ndjson":
+		NDJSONDisplay(w)
+	case "compliance":
+		ComplianceDisplay(bufio.NewWriter(w))
+	case "template":
+		return TemplateDisplay(w, o.Template)
+	default:
+		return fmt.Errorf("invalid output format '%s'", o.Format)
+	}
+	return nil
+}
+
 // TableDisplay generates the tabular output for the ResultList.
 func TableDisplay(w *tabwriter.Writer) {
 	var linePass, lineFail string
@@ -29,7 +176,7 @@ func TableDisplay(w *tabwriter.Writer) {
 		if len(r.Breaches) > 0 {
 			lineFail = r.Breaches[0].String()
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Name, r.Status, linePass, lineFail)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.DisplayName(), r.Status, linePass, lineFail)
 
 		if len(r.Passes) > 1 || len(r.Breaches) > 1 {
 			numPasses := len(r.Passes)
@@ -54,6 +201,31 @@ func TableDisplay(w *tabwriter.Writer) {
 			}
 		}
 	}
+
+	if len(RunResultList.Projects) > 0 {
+		fmt.Fprintf(w, "\nPROJECT\tSTATUS\tCHECKS\tBREACHES\n")
+		for _, ps := range RunResultList.Projects {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", ps.Project, ps.Status, ps.TotalChecks, ps.TotalBreaches)
+		}
+	}
+
+	for _, r := range RunResultList.Results {
+		lines := r.MetadataLines()
+		if r.Status != result.Fail || len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", r.DisplayName())
+		for _, l := range lines {
+			fmt.Fprintf(w, "  %s\n", l)
+		}
+	}
+
+	if RunResultList.Policy != nil {
+		fmt.Fprintf(w, "\n%s\n", RunResultList.Policy.Summary())
+		for _, re := range RunResultList.Policy.FailedRules() {
+			fmt.Fprintf(w, "  - %s\n", re)
+		}
+	}
 	w.Flush()
 }
 
@@ -71,7 +243,7 @@ func SimpleDisplay(w *bufio.Writer) {
 			if successful == 0 {
 				continue
 			}
-			fmt.Fprintf(w, "  ### %s\n", r.Name)
+			fmt.Fprintf(w, "  ### %s\n", r.DisplayName())
 			for _, b := range r.Breaches {
 				if b.GetRemediation().Status != result.RemediationStatusSuccess {
 					continue
@@ -117,52 +289,100 @@ func SimpleDisplay(w *bufio.Writer) {
 		fmt.Fprint(w, "# Breaches were detected\n\n")
 	}
 
+	if len(RunResultList.Projects) > 0 {
+		fmt.Fprint(w, "# Projects\n\n")
+		for _, ps := range RunResultList.Projects {
+			fmt.Fprintf(w, "  - [%s] %s: %d breach(es) in %d check(s)\n",
+				ps.Status, ps.Project, ps.TotalBreaches, ps.TotalChecks)
+		}
+		fmt.Fprintln(w)
+	}
+
+	project := ""
 	for _, r := range RunResultList.Results {
-		if len(r.Breaches) == 0 || r.RemediationStatus == result.RemediationStatusSuccess {
+		if (len(r.Breaches) == 0 && len(r.Errors) == 0) ||
+			r.RemediationStatus == result.RemediationStatusSuccess {
 			continue
 		}
+		if r.Project != project {
+			project = r.Project
+			fmt.Fprintf(w, "## %s\n\n", project)
+		}
 		fmt.Fprintf(w, "  ### %s\n", r.Name)
+		for _, e := range r.Errors {
+			fmt.Fprintf(w, "     -- [error] %s\n", e)
+		}
 		for _, b := range r.Breaches {
 			if b.GetRemediation().Status == result.RemediationStatusSuccess {
 				continue
 			}
 			fmt.Fprintf(w, "     -- %s\n", b)
 		}
+		for _, l := range r.MetadataLines() {
+			fmt.Fprintf(w, "     %s\n", l)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if RunResultList.Policy != nil {
+		fmt.Fprint(w, "# Fail policy\n\n")
+		fmt.Fprintf(w, "  %s\n", RunResultList.Policy.Summary())
+		for _, re := range RunResultList.Policy.FailedRules() {
+			fmt.Fprintf(w, "     -- %s\n", re)
+		}
 		fmt.Fprintln(w)
 	}
 	w.Flush()
 }
 
-// JUnit outputs the checks results in the JUnit XML format.
+// JUnit outputs the checks results in the JUnit XML format, with a test
+// suite for each check type and a test case for each check. Breaches are
+// reported as failures, check execution problems as errors.
 func JUnit(w *bufio.Writer) {
 	tss := JUnitTestSuites{
-		Tests:      RunResultList.TotalChecks,
-		Errors:     RunResultList.TotalBreaches,
+		Time:       RunResultList.Duration.Seconds(),
 		TestSuites: []JUnitTestSuite{},
 	}
 
-	// Create a JUnitTestSuite for each CheckType.
-	for ct, checks := range RunConfig.Checks {
-		ts := JUnitTestSuite{
-			Name:      string(ct),
-			Tests:     RunResultList.CheckCountByType[string(ct)],
-			Errors:    RunResultList.BreachCountByType[string(ct)],
-			TestCases: []JUnitTestCase{},
+	// Results of merged projects get a suite per project and check type.
+	resultsByType := map[string][]result.Result{}
+	for _, r := range RunResultList.Results {
+		suite := r.CheckType
+		if r.Project != "" {
+			suite = r.Project + "/" + r.CheckType
 		}
+		resultsByType[suite] = append(resultsByType[suite], r)
+	}
+	checkTypes := []string{}
+	for ct := range resultsByType {
+		checkTypes = append(checkTypes, ct)
+	}
+	sort.Strings(checkTypes)
 
-		// Create a JUnitTestCase for each Check.
-		for _, c := range checks {
-			tc := JUnitTestCase{
-				Name:      c.GetName(),
-				ClassName: c.GetName(),
-				Errors:    []JUnitError{},
-			}
-
-			for _, b := range RunResultList.GetBreachesByCheckName(c.GetName()) {
-				tc.Errors = append(tc.Errors, JUnitError{Message: b.String()})
+	for _, ct := range checkTypes {
+		ts := JUnitTestSuite{Name: ct, TestCases: []JUnitTestCase{}}
+		for _, r := range resultsByType[ct] {
+			tc := junitTestCase(r)
+			ts.Tests++
+			ts.Failures += len(tc.Failures)
+			ts.Errors += len(tc.Errors)
+			if tc.Skipped != nil {
+				ts.Skipped++
 			}
+			ts.Time += tc.Time
 			ts.TestCases = append(ts.TestCases, tc)
 		}
+		tss.Tests += ts.Tests
+		tss.Failures += ts.Failures
+		tss.Errors += ts.Errors
+		tss.Skipped += ts.Skipped
+		tss.TestSuites = append(tss.TestSuites, ts)
+	}
+
+	if RunResultList.Policy != nil {
+		ts := junitPolicyTestSuite(RunResultList.Policy)
+		tss.Tests += ts.Tests
+		tss.Failures += ts.Failures
 		tss.TestSuites = append(tss.TestSuites, ts)
 	}
 
@@ -177,3 +397,96 @@ func JUnit(w *bufio.Writer) {
 	fmt.Fprintln(w)
 	w.Flush()
 }
+
+func junitTestCase(r result.Result) JUnitTestCase {
+	tc := JUnitTestCase{
+		Name:      r.Name,
+		ClassName: r.DisplayName(),
+		Time:      r.Duration.Seconds(),
+		Properties: []JUnitProperty{
+			{Name: "severity", Value: r.Severity},
+			{Name: "check-type", Value: r.CheckType},
+		},
+	}
+	for _, p := range []JUnitProperty{
+		{Name: "project", Value: r.Project},
+		{Name: "remediation-status", Value: string(r.RemediationStatus)},
+		{Name: "description", Value: r.Description},
+		{Name: "rationale", Value: r.Rationale},
+		{Name: "remediation-guidance", Value: r.RemediationGuidance},
+		{Name: "references", Value: strings.Join(r.References, " ")},
+		{Name: "controls", Value: strings.Join(r.Controls, ",")},
+	} {
+		if p.Value != "" {
+			tc.Properties = append(tc.Properties, p)
+		}
+	}
+
+	if r.Status == result.Skip {
+		tc.Skipped = &JUnitSkipped{Message: r.SkipReason}
+		return tc
+	}
+
+	for _, b := range r.Breaches {
+		if b.GetRemediation().Status == result.RemediationStatusSuccess {
+			continue
+		}
+		tc.Failures = append(tc.Failures, JUnitFailure{Message: b.String(), Type: b.GetSeverity()})
+	}
+	for _, e := range r.Errors {
+		tc.Errors = append(tc.Errors, JUnitError{Message: e})
+	}
+
+	out := []string{}
+	for _, p := range r.Passes {
+		out = append(out, "pass: "+p)
+	}
+	for _, wn := range r.Warnings {
+		out = append(out, "warning: "+wn)
+	}
+	tc.SystemOut = strings.Join(out, "\n")
+	return tc
+}
+
+// junitPolicyTestSuite reports each fail policy rule as a test case, failing
+// when its maximum is exceeded.
+func junitPolicyTestSuite(pe *result.PolicyEvaluation) JUnitTestSuite {
+	ts := JUnitTestSuite{Name: "fail-policy", TestCases: []JUnitTestCase{}}
+	for _, re := range pe.Rules {
+		name := re.Severity
+		if re.CheckType != "" {
+			name = re.CheckType + ": " + name
+		}
+		tc := JUnitTestCase{
+			Name:      name,
+			ClassName: "fail-policy",
+			Properties: []JUnitProperty{
+				{Name: "breaches", Value: strconv.Itoa(re.Breaches)},
+				{Name: "max-breaches", Value: strconv.Itoa(re.MaxBreaches)},
+			},
+		}
+		if re.Failed {
+			tc.Failures = []JUnitFailure{{Message: re.String(), Type: re.Severity}}
+			ts.Failures++
+		}
+		ts.Tests++
+		ts.TestCases = append(ts.TestCases, tc)
+	}
+	if pe.Errors > 0 {
+		tc := JUnitTestCase{
+			Name:       "errors",
+			ClassName:  "fail-policy",
+			Properties: []JUnitProperty{{Name: "checks", Value: strconv.Itoa(pe.Errors)}},
+		}
+		if pe.ErrorsFailed() {
+			tc.Failures = []JUnitFailure{{
+				Message: fmt.Sprintf("%d check(s) with errors", pe.Errors),
+				Type:    "errors",
+			}}
+			ts.Failures++
+		}
+		ts.Tests++
+		ts.TestCases = append(ts.TestCases, tc)
+	}
+	return ts
+}
@@ -0,0 +1,176 @@
+package shipshape
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// DefaultLocationFile is the file to which breaches without a location are
+// attributed in CI annotations when the output does not provide one.
+const DefaultLocationFile = "shipshape.yml"
+
+// CodeQualityIssue is an entry of the GitLab Code Quality report, which is a
+// subset of the Code Climate spec.
+// See https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool.
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+	Content     *CodeQualityContent `json:"content,omitempty"`
+}
+
+// CodeQualityContent is the extended, markdown description of an issue.
+type CodeQualityContent struct {
+	Body string `json:"body"`
+}
+
+type CodeQualityLocation struct {
+	Path  string `json:"path"`
+	Lines struct {
+		Begin int `json:"begin"`
+	} `json:"lines"`
+}
+
+// GitHubDisplay outputs the breaches as GitHub Actions workflow commands, so
+// that they are displayed as annotations on the pull request; breaches without
+// a location are attributed to locationFile.
+func GitHubDisplay(w *bufio.Writer, locationFile string) {
+	if locationFile == "" {
+		locationFile = DefaultLocationFile
+	}
+	for _, r := range RunResultList.Results {
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			file, line := breachFileLine(b, locationFile)
+			position := fmt.Sprintf("file=%s,line=%d", githubEscapeProperty(file), line)
+			if loc := result.BreachGetLocation(b); loc != nil && loc.File != "" && loc.Column > 0 {
+				position += fmt.Sprintf(",col=%d", loc.Column)
+			}
+			msg := strings.Join(append([]string{b.String()}, r.MetadataLines()...), "\n")
+			fmt.Fprintf(w, "::%s %s,title=%s::%s\n",
+				GitHubAnnotationLevel(b.GetSeverity()), position,
+				githubEscapeProperty(r.DisplayName()),
+				githubEscapeData(msg))
+		}
+	}
+	if RunResultList.Policy != nil {
+		for _, re := range RunResultList.Policy.FailedRules() {
+			fmt.Fprintf(w, "::error file=%s,line=1,title=Fail policy::%s\n",
+				githubEscapeProperty(locationFile), githubEscapeData(re.String()))
+		}
+	}
+	w.Flush()
+}
+
+// GitHubAnnotationLevel maps a severity to a workflow command level.
+func GitHubAnnotationLevel(severity string) string {
+	switch config.Severity(severity) {
+	case config.CriticalSeverity, config.HighSeverity:
+		return "error"
+	case config.LowSeverity:
+		return "notice"
+	}
+	return "warning"
+}
+
+// GitLabCodeQualityDisplay outputs the breaches as a GitLab Code Quality
+// report, so that they are displayed on the merge request diff; breaches
+// without a location are attributed to locationFile.
+func GitLabCodeQualityDisplay(w *bufio.Writer, locationFile string) {
+	if locationFile == "" {
+		locationFile = DefaultLocationFile
+	}
+	issues := []CodeQualityIssue{}
+	for _, r := range RunResultList.Results {
+		for _, b := range r.Breaches {
+			if b.GetRemediation().Status == result.RemediationStatusSuccess {
+				continue
+			}
+			file, line := breachFileLine(b, locationFile)
+			issue := CodeQualityIssue{
+				Description: b.String(),
+				CheckName:   r.DisplayName(),
+				Fingerprint: result.ProjectBreachFingerprint(r.Project, b),
+				Severity:    CodeQualitySeverity(b.GetSeverity()),
+				Location:    CodeQualityLocation{Path: file},
+			}
+			if lines := r.MetadataLines(); len(lines) > 0 {
+				issue.Content = &CodeQualityContent{Body: strings.Join(lines, "\n\n")}
+			}
+			issue.Location.Lines.Begin = line
+			issues = append(issues, issue)
+		}
+	}
+	if RunResultList.Policy != nil {
+		for _, re := range RunResultList.Policy.FailedRules() {
+			sum := sha256.Sum256([]byte("fail-policy:" + re.CheckType + ":" + re.Severity))
+			issue := CodeQualityIssue{
+				Description: re.String(),
+				CheckName:   "fail-policy",
+				Fingerprint: hex.EncodeToString(sum[:]),
+				Severity:    CodeQualitySeverity(re.Severity),
+				Location:    CodeQualityLocation{Path: locationFile},
+			}
+			issue.Location.Lines.Begin = 1
+			issues = append(issues, issue)
+		}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		fmt.Fprintf(w, "error occurred while converting to JSON: %s\n", err.Error())
+		w.Flush()
+		return
+	}
+	fmt.Fprintln(w, string(data))
+	w.Flush()
+}
+
+// CodeQualitySeverity maps a severity to a Code Climate severity.
+func CodeQualitySeverity(severity string) string {
+	switch config.Severity(severity) {
+	case config.CriticalSeverity:
+		return "blocker"
+	case config.HighSeverity:
+		return "critical"
+	case config.NormalSeverity:
+		return "major"
+	case config.LowSeverity:
+		return "minor"
+	}
+	return "info"
+}
+
+func breachFileLine(b result.Breach, defaultFile string) (string, int) {
+	file, line := defaultFile, 1
+	if loc := result.BreachGetLocation(b); loc != nil && loc.File != "" {
+		file = loc.File
+		if loc.Line > 0 {
+			line = loc.Line
+		}
+	}
+	return file, line
+}
+
+func githubEscapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func githubEscapeProperty(s string) string {
+	s = githubEscapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
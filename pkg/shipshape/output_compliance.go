@@ -0,0 +1,100 @@
+package shipshape
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+type ControlStatus string
+
+const (
+	// ControlPassing is for controls whose checks all passed.
+	ControlPassing ControlStatus = "passing"
+	// ControlFailing is for controls with at least one failed check.
+	ControlFailing ControlStatus = "failing"
+	// ControlUntested is for controls whose checks were all skipped.
+	ControlUntested ControlStatus = "untested"
+)
+
+// ComplianceControl is a control along with the results of the checks
+// verifying it.
+type ComplianceControl struct {
+	ID      string
+	Status  ControlStatus
+	Results []result.Result
+}
+
+// NewComplianceControls groups the results by the controls they declare,
+// sorted by control identifier.
+func NewComplianceControls(rl *result.ResultList) []ComplianceControl {
+	byControl := map[string][]result.Result{}
+	for _, r := range rl.Results {
+		for _, c := range r.Controls {
+			byControl[c] = append(byControl[c], r)
+		}
+	}
+
+	controls := []ComplianceControl{}
+	for id, results := range byControl {
+		cc := ComplianceControl{ID: id, Status: ControlUntested, Results: results}
+		for _, r := range results {
+			if r.Status == result.Fail {
+				cc.Status = ControlFailing
+				break
+			}
+			if r.Status != result.Skip {
+				cc.Status = ControlPassing
+			}
+		}
+		controls = append(controls, cc)
+	}
+	sort.Slice(controls, func(i, j int) bool { return controls[i].ID < controls[j].ID })
+	return controls
+}
+
+// ComplianceDisplay outputs the results grouped by compliance control.
+func ComplianceDisplay(w *bufio.Writer) {
+	fmt.Fprint(w, "# Compliance report\n\n")
+	controls := NewComplianceControls(&RunResultList)
+	if len(controls) == 0 {
+		fmt.Fprint(w, "No control covered; add 'controls' to the checks to map them to a framework.\n")
+		w.Flush()
+		return
+	}
+
+	counts := map[ControlStatus]int{}
+	for _, cc := range controls {
+		counts[cc.Status]++
+	}
+	fmt.Fprintf(w, "Controls: %d covered, %d passing, %d failing, %d untested\n\n",
+		len(controls), counts[ControlPassing], counts[ControlFailing], counts[ControlUntested])
+
+	for _, cc := range controls {
+		fmt.Fprintf(w, "## %s: %s\n\n", cc.ID, cc.Status)
+		for _, r := range cc.Results {
+			fmt.Fprintf(w, "  - [%s] %s (%s)", r.Status, r.DisplayName(), r.CheckType)
+			if len(r.Breaches) > 0 {
+				fmt.Fprintf(w, ": %d breach(es)", len(r.Breaches))
+			}
+			if r.SkipReason != "" {
+				fmt.Fprintf(w, ": %s", r.SkipReason)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w)
+	}
+
+	uncovered := 0
+	for _, r := range RunResultList.Results {
+		if len(r.Controls) == 0 {
+			uncovered++
+		}
+	}
+	if uncovered > 0 {
+		fmt.Fprintf(w, "%d check(s) not mapped to any control.\n", uncovered)
+	}
+	w.Flush()
+}
@@ -0,0 +1,216 @@
+package shipshape
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// htmlResult is a result along with its breaches prepared for rendering.
+type htmlResult struct {
+	result.Result
+	ReportBreaches []htmlBreach
+	// Set on the first result of each project.
+	Heading string
+}
+
+type htmlBreach struct {
+	reportBreach
+	Remediation *result.Remediation
+}
+
+var htmlTemplate = template.Must(template.New("html").Funcs(template.FuncMap{
+	"diffLineClass": func(l string) string {
+		switch {
+		case strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "---"):
+			return "file"
+		case strings.HasPrefix(l, "@@"):
+			return "hunk"
+		case strings.HasPrefix(l, "+"):
+			return "add"
+		case strings.HasPrefix(l, "-"):
+			return "del"
+		}
+		return ""
+	},
+	"lines": func(s string) []string {
+		return strings.Split(strings.TrimRight(s, "\n"), "\n")
+	},
+	"lower": func(s result.Status) string { return strings.ToLower(string(s)) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Shipshape report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292f; margin: 2em auto; max-width: 1100px; padding: 0 1em; }
+table { border-collapse: collapse; margin: 0 2em 1em 0; display: inline-table; vertical-align: top; }
+th, td { border: 1px solid #d0d7de; padding: 4px 10px; text-align: left; }
+td.count { text-align: right; }
+.check { border: 1px solid #d0d7de; border-left-width: 6px; border-radius: 4px; margin: 1em 0; padding: 0 1em; }
+.check.pass { border-left-color: #2da44e; }
+.check.fail { border-left-color: #cf222e; }
+.check.skip { border-left-color: #8c959f; }
+.meta { color: #57606a; }
+.status-pass { color: #2da44e; font-weight: bold; }
+.status-fail { color: #cf222e; font-weight: bold; }
+.status-skip { color: #8c959f; font-weight: bold; }
+pre.diff { background: #f6f8fa; padding: 0.5em; overflow-x: auto; }
+pre.diff span { display: block; }
+pre.diff .add { background: #dafbe1; }
+pre.diff .del { background: #ffebe9; }
+pre.diff .hunk { color: #8250df; }
+pre.diff .file { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Shipshape report</h1>
+{{- if not .Results}}
+<p>No result available; ensure your shipshape.yml is configured correctly.</p>
+{{- else}}
+<h2>Summary</h2>
+<table>
+<tr><th>Checks</th><th>Passed</th><th>Failed</th><th>Skipped</th><th>Breaches</th></tr>
+<tr><td class="count">{{.Summary.TotalChecks}}</td><td class="count">{{.Summary.Passed}}</td><td class="count">{{.Summary.Failed}}</td><td class="count">{{.Summary.Skipped}}</td><td class="count">{{.Summary.TotalBreaches}}</td></tr>
+</table>
+<table>
+<tr><th>Severity</th><th>Breaches</th></tr>
+{{- range .Summary.BySeverity}}
+<tr><td>{{.Label}}</td><td class="count">{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- if .Summary.ByType}}
+<table>
+<tr><th>Check type</th><th>Breaches</th></tr>
+{{- range .Summary.ByType}}
+<tr><td>{{.Label}}</td><td class="count">{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Summary.RemediationPerformed}}
+<table>
+<tr><th>Remediation</th><th>Count</th></tr>
+{{- range .Summary.RemediationTotals}}
+<tr><td>{{.Label}}</td><td class="count">{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Projects}}
+<h2>Projects</h2>
+<table>
+<tr><th>Project</th><th>Checks</th><th>Breaches</th><th>Status</th></tr>
+{{- range .Projects}}
+<tr><td>{{.Project}}</td><td class="count">{{.TotalChecks}}</td><td class="count">{{.TotalBreaches}}</td><td class="status-{{lower .Status}}">{{.Status}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- with .Policy}}
+<h2>Fail policy</h2>
+<p class="{{if .Failed}}status-fail{{else}}status-pass{{end}}">{{.Summary}}</p>
+{{- if .Rules}}
+<table>
+<tr><th>Check type</th><th>Severity</th><th>Breaches</th><th>Max</th><th>Status</th></tr>
+{{- range .Rules}}
+<tr><td>{{if .CheckType}}{{.CheckType}}{{else}}all{{end}}</td><td>{{.Severity}}</td><td class="count">{{.Breaches}}</td><td class="count">{{.MaxBreaches}}</td><td>{{if .Failed}}<span class="status-fail">Fail</span>{{else}}Pass{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+{{- range .Results}}
+{{- if .Heading}}
+<h2>{{.Heading}}</h2>
+{{- end}}
+<div class="check {{lower .Status}}">
+<h3>{{.DisplayName}}</h3>
+<p class="meta">Type: <code>{{.CheckType}}</code> | Severity: <code>{{.Severity}}</code> | Status: <span class="status-{{lower .Status}}">{{.Status}}</span>{{if .RemediationStatus}} | Remediation: {{.RemediationStatus}}{{end}}{{if .SkipReason}} ({{.SkipReason}}){{end}}</p>
+{{- if .Description}}
+<p><strong>Description:</strong> {{.Description}}</p>
+{{- end}}
+{{- if .Rationale}}
+<p><strong>Rationale:</strong> {{.Rationale}}</p>
+{{- end}}
+{{- if .RemediationGuidance}}
+<p><strong>Remediation:</strong> {{.RemediationGuidance}}</p>
+{{- end}}
+{{- if .References}}
+<h4>References</h4>
+<ul>{{range .References}}<li><a href="{{.}}">{{.}}</a></li>{{end}}</ul>
+{{- end}}
+{{- if .Errors}}
+<h4>Errors</h4>
+<ul>{{range .Errors}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .ReportBreaches}}
+<h4>Breaches</h4>
+<ul>
+{{- range .ReportBreaches}}
+<li>{{.Title}}
+{{- if .Values}}
+<ul>{{range .Values}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .Diff}}
+<pre class="diff">{{range lines .Diff}}<span class="{{diffLineClass .}}">{{.}}</span>{{end}}</pre>
+{{- end}}
+{{- if .Remediation.Status}}
+<p class="meta">Remediation: {{.Remediation.Status}}</p>
+{{- if .Remediation.Messages}}
+<ul>{{range .Remediation.Messages}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- end}}
+</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Warnings}}
+<h4>Warnings</h4>
+<ul>{{range .Warnings}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+{{- if .Passes}}
+<h4>Passes</h4>
+<ul>{{range .Passes}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// HTMLDisplay outputs a self-contained HTML report of the results.
+func HTMLDisplay(w *bufio.Writer) {
+	data := struct {
+		Summary  reportSummary
+		Projects []result.ProjectSummary
+		Policy   *result.PolicyEvaluation
+		Results  []htmlResult
+	}{
+		Summary:  newReportSummary(&RunResultList),
+		Projects: RunResultList.Projects,
+		Policy:   RunResultList.Policy,
+	}
+
+	for i, r := range RunResultList.Results {
+		hr := htmlResult{Result: r}
+		if i == 0 || r.Project != RunResultList.Results[i-1].Project {
+			hr.Heading = "Results"
+			if r.Project != "" {
+				hr.Heading += ": " + r.Project
+			}
+		}
+		for _, b := range r.Breaches {
+			hr.ReportBreaches = append(hr.ReportBreaches, htmlBreach{
+				reportBreach: newReportBreach(r, b),
+				Remediation:  b.GetRemediation(),
+			})
+		}
+		data.Results = append(data.Results, hr)
+	}
+
+	if err := htmlTemplate.Execute(w, data); err != nil {
+		fmt.Fprintf(w, "error occurred while rendering HTML: %s\n", err.Error())
+	}
+	w.Flush()
+}
@@ -0,0 +1,164 @@
+package shipshape
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// MarkdownDisplay outputs a summary of the results followed by the details of
+// each check, in a format suitable for merge request comments.
+func MarkdownDisplay(w *bufio.Writer) {
+	fmt.Fprint(w, "# Shipshape report\n\n")
+	if len(RunResultList.Results) == 0 {
+		fmt.Fprint(w, "No result available; ensure your shipshape.yml is configured correctly.\n")
+		w.Flush()
+		return
+	}
+
+	s := newReportSummary(&RunResultList)
+	fmt.Fprint(w, "## Summary\n\n")
+	fmt.Fprint(w, "| Checks | Passed | Failed | Skipped | Breaches |\n")
+	fmt.Fprint(w, "| -----: | -----: | -----: | ------: | -------: |\n")
+	fmt.Fprintf(w, "| %d | %d | %d | %d | %d |\n\n",
+		s.TotalChecks, s.Passed, s.Failed, s.Skipped, s.TotalBreaches)
+
+	markdownCountTable(w, "Severity", s.BySeverity)
+	if len(s.ByType) > 0 {
+		markdownCountTable(w, "Check type", s.ByType)
+	}
+	if s.RemediationPerformed {
+		markdownCountTable(w, "Remediation", s.RemediationTotals)
+	}
+
+	if len(RunResultList.Projects) > 0 {
+		fmt.Fprint(w, "## Projects\n\n")
+		fmt.Fprint(w, "| Project | Checks | Breaches | Status |\n")
+		fmt.Fprint(w, "| ------- | -----: | -------: | ------ |\n")
+		for _, ps := range RunResultList.Projects {
+			fmt.Fprintf(w, "| %s | %d | %d | %s |\n",
+				markdownEscapeCell(ps.Project), ps.TotalChecks, ps.TotalBreaches, ps.Status)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if pe := RunResultList.Policy; pe != nil {
+		fmt.Fprint(w, "## Fail policy\n\n")
+		fmt.Fprintf(w, "%s\n\n", pe.Summary())
+		if len(pe.Rules) > 0 {
+			fmt.Fprint(w, "| Check type | Severity | Breaches | Max | Status |\n")
+			fmt.Fprint(w, "| ---------- | -------- | -------: | --: | ------ |\n")
+			for _, re := range pe.Rules {
+				ct, status := "all", "Pass"
+				if re.CheckType != "" {
+					ct = re.CheckType
+				}
+				if re.Failed {
+					status = "**Fail**"
+				}
+				fmt.Fprintf(w, "| %s | %s | %d | %d | %s |\n",
+					markdownEscapeCell(ct), re.Severity, re.Breaches, re.MaxBreaches, status)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	for i, r := range RunResultList.Results {
+		if i == 0 || r.Project != RunResultList.Results[i-1].Project {
+			if r.Project != "" {
+				fmt.Fprintf(w, "## Results: %s\n\n", r.Project)
+			} else {
+				fmt.Fprint(w, "## Results\n\n")
+			}
+		}
+		icon := ":white_check_mark:"
+		switch r.Status {
+		case result.Fail:
+			icon = ":x:"
+		case result.Skip:
+			icon = ":fast_forward:"
+		}
+		fmt.Fprintf(w, "### %s %s\n\n", icon, r.DisplayName())
+		fmt.Fprintf(w, "Type: `%s` | Severity: `%s` | Status: **%s**", r.CheckType, r.Severity, r.Status)
+		if r.RemediationStatus != "" {
+			fmt.Fprintf(w, " | Remediation: **%s**", r.RemediationStatus)
+		}
+		if r.SkipReason != "" {
+			fmt.Fprintf(w, " (%s)", r.SkipReason)
+		}
+		fmt.Fprint(w, "\n\n")
+
+		for _, m := range []struct{ label, value string }{
+			{"Description", r.Description},
+			{"Rationale", r.Rationale},
+			{"Remediation", r.RemediationGuidance},
+		} {
+			if m.value != "" {
+				fmt.Fprintf(w, "**%s:** %s\n\n", m.label, m.value)
+			}
+		}
+		markdownList(w, "References", r.References)
+		markdownList(w, "Errors", r.Errors)
+		if len(r.Breaches) > 0 {
+			fmt.Fprint(w, "#### Breaches\n\n")
+			for _, b := range r.Breaches {
+				markdownBreach(w, newReportBreach(r, b), b.GetRemediation())
+			}
+			fmt.Fprintln(w)
+		}
+		markdownList(w, "Warnings", r.Warnings)
+		markdownList(w, "Passes", r.Passes)
+	}
+	w.Flush()
+}
+
+func markdownCountTable(w *bufio.Writer, label string, entries []countEntry) {
+	fmt.Fprintf(w, "| %s | Count |\n", label)
+	fmt.Fprintf(w, "| %s | ----: |\n", strings.Repeat("-", len(label)))
+	for _, e := range entries {
+		fmt.Fprintf(w, "| %s | %d |\n", markdownEscapeCell(e.Label), e.Count)
+	}
+	fmt.Fprintln(w)
+}
+
+func markdownBreach(w *bufio.Writer, rb reportBreach, rem *result.Remediation) {
+	fmt.Fprintf(w, "- %s\n", markdownIndent(rb.Title))
+	for _, v := range rb.Values {
+		fmt.Fprintf(w, "  - %s\n", markdownIndent(v))
+	}
+	if rb.Diff != "" {
+		fmt.Fprint(w, "\n  ```diff\n")
+		for _, l := range strings.Split(strings.TrimRight(rb.Diff, "\n"), "\n") {
+			fmt.Fprintf(w, "  %s\n", l)
+		}
+		fmt.Fprint(w, "  ```\n")
+	}
+	if rem.Status != "" {
+		fmt.Fprintf(w, "  - _remediation: %s_\n", rem.Status)
+		for _, msg := range rem.Messages {
+			fmt.Fprintf(w, "    - %s\n", markdownIndent(msg))
+		}
+	}
+}
+
+func markdownList(w *bufio.Writer, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "#### %s\n\n", title)
+	for _, i := range items {
+		fmt.Fprintf(w, "- %s\n", markdownIndent(i))
+	}
+	fmt.Fprintln(w)
+}
+
+// markdownIndent keeps multi-line values inside their list item.
+func markdownIndent(s string) string {
+	return strings.ReplaceAll(strings.TrimRight(s, "\n"), "\n", "\n  ")
+}
+
+func markdownEscapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,97 @@
+package shipshape
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// NDJSONWriter returns an event handler writing each event to the writer as
+// a single line of JSON.
+func NDJSONWriter(w io.Writer) EventHandler {
+	lock := sync.Mutex{}
+	return func(e Event) {
+		data, err := json.Marshal(e)
+		if err != nil {
+			log.WithError(err).WithField("event", e.Type).Error("unable to convert event to json")
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintln(w, string(data))
+	}
+}
+
+// NDJSONDisplay writes the events of a completed run, for when the output
+// could not be streamed while the checks were running.
+func NDJSONDisplay(w io.Writer) {
+	h := NDJSONWriter(w)
+	h(Event{
+		Type:   EventRunStarted,
+		Time:   RunResultList.StartTime,
+		Totals: &RunTotals{TotalChecks: RunResultList.TotalChecks},
+	})
+	for i := range RunResultList.Results {
+		r := RunResultList.Results[i]
+		h(Event{
+			Type:      EventCheckFinished,
+			Time:      r.StartTime.Add(r.Duration),
+			Project:   r.Project,
+			CheckType: r.CheckType,
+			CheckName: r.Name,
+			Result:    &r,
+		})
+	}
+	h(Event{
+		Type:   EventRunFinished,
+		Time:   RunResultList.StartTime.Add(RunResultList.Duration),
+		Totals: newRunTotals(&RunResultList),
+	})
+}
+
+// StreamOutputs subscribes the streaming outputs to the run events and
+// returns the remaining outputs, to be written once the run is complete,
+// along with a function closing the streamed files.
+func StreamOutputs(stdout io.Writer, outputs []config.Output) ([]config.Output, func() error, error) {
+	remaining := []config.Output{}
+	files := []*os.File{}
+	closeFiles := func() error {
+		var err error
+		for _, f := range files {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}
+
+	for _, o := range outputs {
+		if o.Format != "ndjson" {
+			remaining = append(remaining, o)
+			continue
+		}
+
+		if o.File == "" {
+			AddEventHandler(NDJSONWriter(stdout))
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(o.File), 0755); err != nil {
+			closeFiles()
+			return nil, nil, err
+		}
+		f, err := os.Create(o.File)
+		if err != nil {
+			closeFiles()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		AddEventHandler(NDJSONWriter(f))
+	}
+	return remaining, closeFiles, nil
+}
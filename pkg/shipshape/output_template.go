@@ -0,0 +1,151 @@
+package shipshape
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+	"github.com/salsadigitalauorg/shipshape/pkg/utils"
+)
+
+// TemplateFuncs are the helper functions available in user-defined output
+// templates, in addition to the text/template builtins.
+var TemplateFuncs = template.FuncMap{
+	// Filters take a comma-separated list of values as the first argument so
+	// that they can be used in pipelines, e.g.
+	// {{ .Results | filterBySeverity "high,critical" }}.
+	"filterBySeverity": func(severities string, items any) (any, error) {
+		return filterItems(items, severities, func(r result.Result) string { return r.Severity },
+			func(b result.Breach) string { return b.GetSeverity() })
+	},
+	"filterByType": func(checkTypes string, items any) (any, error) {
+		return filterItems(items, checkTypes, func(r result.Result) string { return r.CheckType },
+			func(b result.Breach) string { return b.GetCheckType() })
+	},
+	"filterByStatus": func(statuses string, results []result.Result) []result.Result {
+		filtered := []result.Result{}
+		for _, r := range results {
+			if utils.StringSliceContains(splitList(statuses), string(r.Status)) {
+				filtered = append(filtered, r)
+			}
+		}
+		return filtered
+	},
+	"breaches":      templateBreaches,
+	"keyLabel":      result.BreachGetKeyLabel,
+	"key":           result.BreachGetKey,
+	"valueLabel":    result.BreachGetValueLabel,
+	"value":         result.BreachGetValue,
+	"values":        result.BreachGetValues,
+	"expectedValue": result.BreachGetExpectedValue,
+	"join":          func(sep string, s []string) string { return strings.Join(s, sep) },
+	"lower":         strings.ToLower,
+	"upper":         strings.ToUpper,
+	"trim":          strings.TrimSpace,
+	"replace":       func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"csv":           templateCsv,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// TemplateDisplay renders the results using the provided Go template file.
+// The template is executed against the ResultList.
+func TemplateDisplay(w io.Writer, templateFile string) error {
+	data, err := os.ReadFile(templateFile)
+	if err != nil {
+		return fmt.Errorf("unable to read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(templateFile)).
+		Funcs(TemplateFuncs).Parse(string(data))
+	if err != nil {
+		return fmt.Errorf("unable to parse template: %w", err)
+	}
+	if err := tmpl.Execute(w, RunResultList); err != nil {
+		return fmt.Errorf("unable to render template: %w", err)
+	}
+	return nil
+}
+
+// filterItems filters a list of results or breaches on the value returned by
+// the getters.
+func filterItems(items any, list string, resultGetter func(result.Result) string, breachGetter func(result.Breach) string) (any, error) {
+	allowed := splitList(list)
+	switch v := items.(type) {
+	case []result.Result:
+		filtered := []result.Result{}
+		for _, r := range v {
+			if utils.StringSliceContains(allowed, resultGetter(r)) {
+				filtered = append(filtered, r)
+			}
+		}
+		return filtered, nil
+	case []result.Breach:
+		filtered := []result.Breach{}
+		for _, b := range v {
+			if utils.StringSliceContains(allowed, breachGetter(b)) {
+				filtered = append(filtered, b)
+			}
+		}
+		return filtered, nil
+	}
+	return nil, fmt.Errorf("unable to filter items of type %T", items)
+}
+
+// templateBreaches returns the breaches of a ResultList, a Result or a list
+// of Results.
+func templateBreaches(v any) ([]result.Breach, error) {
+	breaches := []result.Breach{}
+	switch r := v.(type) {
+	case result.ResultList:
+		return templateBreaches(r.Results)
+	case *result.ResultList:
+		return templateBreaches(r.Results)
+	case result.Result:
+		breaches = append(breaches, r.Breaches...)
+	case []result.Result:
+		for _, res := range r {
+			breaches = append(breaches, res.Breaches...)
+		}
+	default:
+		return nil, fmt.Errorf("unable to get breaches from type %T", v)
+	}
+	return breaches, nil
+}
+
+// templateCsv renders the values as a single CSV record, without the
+// trailing newline.
+func templateCsv(values ...any) (string, error) {
+	record := []string{}
+	for _, v := range values {
+		switch s := v.(type) {
+		case []string:
+			record = append(record, strings.Join(s, "\n"))
+		default:
+			record = append(record, fmt.Sprint(s))
+		}
+	}
+	buf := &bytes.Buffer{}
+	cw := csv.NewWriter(buf)
+	if err := cw.Write(record); err != nil {
+		return "", err
+	}
+	cw.Flush()
+	return strings.TrimSuffix(buf.String(), "\n"), cw.Error()
+}
+
+func splitList(list string) []string {
+	items := []string{}
+	for _, i := range strings.Split(list, ",") {
+		items = append(items, strings.TrimSpace(i))
+	}
+	return items
+}
@@ -3,8 +3,12 @@ package shipshape_test
 import (
 	"bufio"
 	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"text/tabwriter"
+	"time"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
@@ -128,6 +132,19 @@ func TestSimpleDisplay(t *testing.T) {
 		assert.Equal("# Breaches were detected\n\n  ### b\n     -- Fail b\n\n", buf.String())
 	})
 
+	t.Run("errorsDetected", func(t *testing.T) {
+		RunResultList = result.NewResultList(false)
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		RunResultList.Results = append(RunResultList.Results, result.Result{
+			Name:   "b",
+			Status: result.Fail,
+			Errors: []string{"check panicked: oops"},
+		})
+		SimpleDisplay(w)
+		assert.Equal("# Breaches were detected\n\n  ### b\n     -- [error] check panicked: oops\n\n", buf.String())
+	})
+
 	t.Run("topShapeRemediating", func(t *testing.T) {
 		RunResultList = result.ResultList{RemediationPerformed: true}
 		var buf bytes.Buffer
@@ -222,58 +239,678 @@ func TestSimpleDisplay(t *testing.T) {
 			"# Non-remediated breaches\n\n"+
 			"  ### a\n     -- \n\n", buf.String())
 	})
-}
 
-type testCheck struct{ config.CheckBase }
+	t.Run("metadata", func(t *testing.T) {
+		RunResultList = result.ResultList{
+			Results: []result.Result{{
+				Name:                "a",
+				Status:              result.Fail,
+				Breaches:            []result.Breach{&result.ValueBreach{Value: "Fail a"}},
+				Rationale:           "Because.",
+				References:          []string{"https://example.com"},
+				RemediationGuidance: "Fix it.",
+			}},
+			TotalBreaches: 1,
+		}
 
-const testCheckType config.CheckType = "test-check"
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		SimpleDisplay(w)
+		assert.Equal("# Breaches were detected\n\n  ### a\n     -- Fail a\n"+
+			"     Rationale: Because.\n"+
+			"     Remediation: Fix it.\n"+
+			"     References: https://example.com\n\n", buf.String())
+	})
+
+	t.Run("failPolicy", func(t *testing.T) {
+		RunResultList = result.ResultList{
+			Results: []result.Result{{
+				Name:     "a",
+				Status:   result.Fail,
+				Breaches: []result.Breach{&result.ValueBreach{Value: "Fail a"}},
+			}},
+			TotalBreaches: 1,
+			Policy: &result.PolicyEvaluation{
+				FailSeverity: "high",
+				Failed:       true,
+				Rules: []result.PolicyRuleEvaluation{
+					{Severity: "critical"},
+					{Severity: "high", Breaches: 1, Failed: true},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		SimpleDisplay(w)
+		assert.Equal("# Breaches were detected\n\n  ### a\n     -- Fail a\n\n"+
+			"# Fail policy\n\n"+
+			"  Fail policy failed (fail severity: high); 1 rule(s) exceeded\n"+
+			"     -- 1 high breach(es), max allowed 0\n\n", buf.String())
+	})
+
+	t.Run("projects", func(t *testing.T) {
+		rlA := result.NewResultList(false)
+		rlA.IncrChecks("file", 1)
+		rlA.AddResult(result.Result{Name: "a", Status: result.Fail,
+			Breaches: []result.Breach{&result.ValueBreach{Value: "Fail a"}}})
+		rlB := result.NewResultList(false)
+		rlB.IncrChecks("file", 1)
+		rlB.AddResult(result.Result{Name: "a", Status: result.Pass})
+		RunResultList = result.Merge([]string{"site-a", "site-b"}, []result.ResultList{rlA, rlB})
+
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		SimpleDisplay(w)
+		assert.Equal("# Breaches were detected\n\n"+
+			"# Projects\n\n"+
+			"  - [Fail] site-a: 1 breach(es) in 1 check(s)\n"+
+			"  - [Pass] site-b: 0 breach(es) in 1 check(s)\n\n"+
+			"## site-a\n\n  ### a\n     -- Fail a\n\n", buf.String())
+
+		buf = bytes.Buffer{}
+		w = bufio.NewWriter(&buf)
+		MarkdownDisplay(w)
+		assert.Contains(buf.String(), "| site-a | 1 | 1 | Fail |\n| site-b | 1 | 0 | Pass |\n")
+		assert.Contains(buf.String(), "## Results: site-a\n\n### :x: [site-a] a\n")
+		assert.Contains(buf.String(), "## Results: site-b\n\n### :white_check_mark: [site-b] a\n")
+
+		buf = bytes.Buffer{}
+		w = bufio.NewWriter(&buf)
+		HTMLDisplay(w)
+		assert.Contains(buf.String(), `<tr><td>site-a</td><td class="count">1</td><td class="count">1</td><td class="status-fail">Fail</td></tr>`)
+		assert.Contains(buf.String(), "<h2>Results: site-b</h2>")
+
+		buf = bytes.Buffer{}
+		w = bufio.NewWriter(&buf)
+		JUnit(w)
+		assert.Contains(buf.String(), `<testsuite name="site-a/"`)
+		assert.Contains(buf.String(), `<testsuite name="site-b/"`)
+	})
+}
 
 func TestJUnit(t *testing.T) {
 	assert := assert.New(t)
 
-	RunResultList = result.NewResultList(false)
-	var buf bytes.Buffer
-	w := bufio.NewWriter(&buf)
-	JUnit(w)
-	assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
-<testsuites tests="0" errors="0"></testsuites>
+	t.Run("noResult", func(t *testing.T) {
+		RunResultList = result.NewResultList(false)
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		JUnit(w)
+		assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0" errors="0" skipped="0" time="0"></testsuites>
 `, buf.String())
+	})
 
-	RunConfig.Checks = config.CheckMap{testCheckType: []config.Check{&testCheck{
-		CheckBase: config.CheckBase{Name: "a"},
-	}}}
-	RunResultList.Results = append(RunResultList.Results, result.Result{
-		Name: "a", Status: result.Pass})
-	buf = bytes.Buffer{}
-	JUnit(w)
-	assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
-<testsuites tests="0" errors="0">
-    <testsuite name="test-check" tests="0" errors="0">
-        <testcase name="a" classname="a"></testcase>
+	t.Run("pass", func(t *testing.T) {
+		RunResultList = result.NewResultList(false)
+		RunResultList.Results = append(RunResultList.Results, result.Result{
+			Name: "a", CheckType: "test-check", Severity: "normal",
+			Status: result.Pass, Passes: []string{"Pass a"}})
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		JUnit(w)
+		assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="1" failures="0" errors="0" skipped="0" time="0">
+    <testsuite name="test-check" tests="1" failures="0" errors="0" skipped="0" time="0">
+        <testcase name="a" classname="a" time="0">
+            <properties>
+                <property name="severity" value="normal"></property>
+                <property name="check-type" value="test-check"></property>
+            </properties>
+            <system-out>pass: Pass a</system-out>
+        </testcase>
+    </testsuite>
+</testsuites>
+`, buf.String())
+	})
+
+	t.Run("failuresErrorsSkipped", func(t *testing.T) {
+		RunResultList = result.NewResultList(false)
+		RunResultList.Results = []result.Result{
+			{
+				Name: "b", CheckType: "test-check", Severity: "high",
+				Status:   result.Fail,
+				Warnings: []string{"Warn b"},
+				Breaches: []result.Breach{
+					&result.ValueBreach{Severity: "high", Value: "Fail b"},
+				},
+			},
+			{
+				Name: "c", CheckType: "test-check", Severity: "normal",
+				Status: result.Fail,
+				Errors: []string{"check panicked: oops"},
+			},
+			{
+				Name: "d", CheckType: "test-db-check", Severity: "normal",
+				Status:     result.Skip,
+				SkipReason: "database checks excluded",
+			},
+		}
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		JUnit(w)
+		assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1" errors="1" skipped="1" time="0">
+    <testsuite name="test-check" tests="2" failures="1" errors="1" skipped="0" time="0">
+        <testcase name="b" classname="b" time="0">
+            <properties>
+                <property name="severity" value="high"></property>
+                <property name="check-type" value="test-check"></property>
+            </properties>
+            <failure message="Fail b" type="high"></failure>
+            <system-out>warning: Warn b</system-out>
+        </testcase>
+        <testcase name="c" classname="c" time="0">
+            <properties>
+                <property name="severity" value="normal"></property>
+                <property name="check-type" value="test-check"></property>
+            </properties>
+            <error message="check panicked: oops"></error>
+        </testcase>
+    </testsuite>
+    <testsuite name="test-db-check" tests="1" failures="0" errors="0" skipped="1" time="0">
+        <testcase name="d" classname="d" time="0">
+            <properties>
+                <property name="severity" value="normal"></property>
+                <property name="check-type" value="test-db-check"></property>
+            </properties>
+            <skipped message="database checks excluded"></skipped>
+        </testcase>
     </testsuite>
 </testsuites>
 `, buf.String())
+	})
 
-	RunConfig.Checks[testCheckType] = append(RunConfig.Checks[testCheckType], &testCheck{
-		CheckBase: config.CheckBase{Name: "b"},
+	t.Run("failPolicy", func(t *testing.T) {
+		RunResultList = result.ResultList{
+			Results: []result.Result{},
+			Policy: &result.PolicyEvaluation{
+				FailSeverity: "high",
+				Failed:       true,
+				Rules: []result.PolicyRuleEvaluation{
+					{Severity: "critical"},
+					{CheckType: "phpstan", Severity: "high", Breaches: 2, MaxBreaches: 1, Failed: true},
+				},
+				Errors: 1,
+			},
+		}
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		JUnit(w)
+		assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2" errors="0" skipped="0" time="0">
+    <testsuite name="fail-policy" tests="3" failures="2" errors="0" skipped="0" time="0">
+        <testcase name="critical" classname="fail-policy" time="0">
+            <properties>
+                <property name="breaches" value="0"></property>
+                <property name="max-breaches" value="0"></property>
+            </properties>
+        </testcase>
+        <testcase name="phpstan: high" classname="fail-policy" time="0">
+            <properties>
+                <property name="breaches" value="2"></property>
+                <property name="max-breaches" value="1"></property>
+            </properties>
+            <failure message="2 high breach(es), max allowed 1 for check type phpstan" type="high"></failure>
+        </testcase>
+        <testcase name="errors" classname="fail-policy" time="0">
+            <properties>
+                <property name="checks" value="1"></property>
+            </properties>
+            <failure message="1 check(s) with errors" type="errors"></failure>
+        </testcase>
+    </testsuite>
+</testsuites>
+`, buf.String())
 	})
+}
+
+func TestParseOutputs(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("single", func(t *testing.T) {
+		outputs, err := ParseOutputs([]string{"simple"}, "")
+		assert.NoError(err)
+		assert.Equal([]config.Output{{Format: "simple"}}, outputs)
+	})
+
+	t.Run("multiple", func(t *testing.T) {
+		outputs, err := ParseOutputs([]string{
+			"junit=reports/junit.xml", "json=reports/result.json", "simple"}, "")
+		assert.NoError(err)
+		assert.Equal([]config.Output{
+			{Format: "junit", File: "reports/junit.xml"},
+			{Format: "json", File: "reports/result.json"},
+			{Format: "simple"},
+		}, outputs)
+	})
+
+	t.Run("invalidFormat", func(t *testing.T) {
+		_, err := ParseOutputs([]string{"foo=bar.txt"}, "")
+		assert.EqualError(err, "invalid output format 'foo'; needs to be one of: "+
+			strings.Join(OutputFormats, "|"))
+	})
+
+	t.Run("multipleStdout", func(t *testing.T) {
+		_, err := ParseOutputs([]string{"json", "simple"}, "")
+		assert.ErrorContains(err, "only one output can be written to stdout")
+	})
+
+	t.Run("template", func(t *testing.T) {
+		outputs, err := ParseOutputs([]string{"template=report.csv"}, "report.tmpl")
+		assert.NoError(err)
+		assert.Equal([]config.Output{
+			{Format: "template", File: "report.csv", Template: "report.tmpl"},
+		}, outputs)
+
+		_, err = ParseOutputs([]string{"template"}, "")
+		assert.EqualError(err, "a template file is required for the template output")
+	})
+}
+
+func TestWriteOutputs(t *testing.T) {
+	assert := assert.New(t)
+
+	RunResultList = result.NewResultList(false)
 	RunResultList.Results = append(RunResultList.Results, result.Result{
-		Name:   "b",
-		Status: result.Fail,
-		Breaches: []result.Breach{
-			&result.ValueBreach{Value: "Fail b"},
+		Name: "a", Status: result.Pass})
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	err := WriteOutputs(&buf, []config.Output{
+		{Format: "json", File: filepath.Join(dir, "reports", "result.json")},
+		{Format: "simple"},
+	})
+	assert.NoError(err)
+	assert.Equal("Ship is in top shape; no breach detected!\n", buf.String())
+
+	data, err := os.ReadFile(filepath.Join(dir, "reports", "result.json"))
+	assert.NoError(err)
+	assert.Contains(string(data), `"name":"a"`)
+}
+
+func TestMarkdownDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("noResult", func(t *testing.T) {
+		RunResultList = result.NewResultList(false)
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		MarkdownDisplay(w)
+		assert.Equal("# Shipshape report\n\n"+
+			"No result available; ensure your shipshape.yml is configured correctly.\n",
+			buf.String())
+	})
+
+	t.Run("breaches", func(t *testing.T) {
+		RunResultList = result.NewResultList(false)
+		RunResultList.IncrChecks("filediff", 1)
+		RunResultList.IncrChecks("test-check", 1)
+		RunResultList.AddResult(result.Result{
+			Name:      "a",
+			CheckType: "filediff",
+			Severity:  "high",
+			Status:    result.Fail,
+			Breaches: []result.Breach{&result.ValueBreach{
+				ValueLabel: "Target file b is different from Source file a",
+				Value:      "diff: \n--- a\n+++ b\n@@ -1 +1 @@\n-foo\n+bar\n",
+			}},
+		})
+		RunResultList.AddResult(result.Result{
+			Name:      "b",
+			CheckType: "test-check",
+			Severity:  "normal",
+			Status:    result.Fail,
+			Passes:    []string{"Pass b"},
+			Breaches: []result.Breach{&result.KeyValuesBreach{
+				KeyLabel:   "role",
+				Key:        "editor",
+				ValueLabel: "permissions",
+				Values:     []string{"administer modules", "administer site"},
+			}},
+		})
+
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		MarkdownDisplay(w)
+		assert.Equal(`# Shipshape report
+
+## Summary
+
+| Checks | Passed | Failed | Skipped | Breaches |
+| -----: | -----: | -----: | ------: | -------: |
+| 2 | 0 | 2 | 0 | 2 |
+
+| Severity | Count |
+| -------- | ----: |
+| critical | 0 |
+| high | 1 |
+| normal | 1 |
+| low | 0 |
+
+| Check type | Count |
+| ---------- | ----: |
+| filediff | 1 |
+| test-check | 1 |
+
+## Results
+
+### :x: a
+
+Type: `+"`filediff`"+` | Severity: `+"`high`"+` | Status: **Fail**
+
+#### Breaches
+
+- Target file b is different from Source file a
+
+  `+"```diff"+`
+  --- a
+  +++ b
+  @@ -1 +1 @@
+  -foo
+  +bar
+  `+"```"+`
+
+### :x: b
+
+Type: `+"`test-check`"+` | Severity: `+"`normal`"+` | Status: **Fail**
+
+#### Breaches
+
+- [role:editor] permissions
+  - administer modules
+  - administer site
+
+#### Passes
+
+- Pass b
+
+`, buf.String())
+	})
+}
+
+func TestHTMLDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	RunResultList = result.NewResultList(false)
+	RunResultList.IncrChecks("filediff", 1)
+	RunResultList.AddResult(result.Result{
+		Name:      "<a>",
+		CheckType: "filediff",
+		Severity:  "high",
+		Status:    result.Fail,
+		Breaches: []result.Breach{&result.ValueBreach{
+			ValueLabel: "Target file b is different from Source file a",
+			Value:      "diff: \n--- a\n+++ b\n@@ -1 +1 @@\n-foo\n+bar\n",
+		}},
+	})
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	HTMLDisplay(w)
+	assert.Contains(buf.String(), "<h3>&lt;a&gt;</h3>")
+	assert.Contains(buf.String(), `<tr><td>high</td><td class="count">1</td></tr>`)
+	assert.Contains(buf.String(), `<span class="del">-foo</span><span class="add">&#43;bar</span>`)
+	assert.True(strings.HasSuffix(buf.String(), "</html>\n"))
+}
+
+func TestTemplateDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	RunResultList = result.NewResultList(false)
+	RunResultList.AddResult(result.Result{
+		Name:      "a",
+		CheckType: "file",
+		Severity:  "high",
+		Status:    result.Fail,
+		Breaches: []result.Breach{&result.ValueBreach{
+			CheckName: "a", CheckType: "file", Severity: "high",
+			ValueLabel: "illegal file", Value: "foo, bar.php"},
 		},
 	})
-	buf = bytes.Buffer{}
+	RunResultList.AddResult(result.Result{
+		Name:      "b",
+		CheckType: "yaml",
+		Severity:  "low",
+		Status:    result.Fail,
+		Breaches: []result.Breach{&result.ValueBreach{
+			CheckName: "b", CheckType: "yaml", Severity: "low", Value: "low breach"},
+		},
+	})
+
+	var buf bytes.Buffer
+	err := TemplateDisplay(&buf, "testdata/templates/breaches.csv.tmpl")
+	assert.NoError(err)
+	assert.Equal("check,type,severity,breach\n"+
+		"a,file,high,\"[illegal file] foo, bar.php\"\n"+
+		"a: fail\n", buf.String())
+
+	err = TemplateDisplay(&buf, "testdata/templates/nonexistent.tmpl")
+	assert.ErrorContains(err, "unable to read template")
+}
+
+func TestGitHubDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	RunResultList = result.NewResultList(false)
+	RunResultList.Results = []result.Result{
+		{Name: "a", Status: result.Pass},
+		{
+			Name:   "b, the check",
+			Status: result.Fail,
+			Breaches: []result.Breach{
+				&result.KeyValuesBreach{
+					Severity: "high",
+					Key:      "file: web/foo.php",
+					Values:   []string{"line 3: 100% wrong"},
+					Location: &result.Location{File: "web/foo.php", Line: 3, Column: 5},
+				},
+				&result.ValueBreach{Severity: "low", Value: "no location"},
+				&result.ValueBreach{Severity: "normal", Value: "normal breach"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	GitHubDisplay(w, "")
+	assert.Equal(
+		"::error file=web/foo.php,line=3,col=5,title=b%2C the check::file: web/foo.php:%0A        - line 3: 100%25 wrong\n"+
+			"::notice file=shipshape.yml,line=1,title=b%2C the check::no location\n"+
+			"::warning file=shipshape.yml,line=1,title=b%2C the check::normal breach\n",
+		buf.String())
+	buf.Reset()
+	GitHubDisplay(w, ".shipshape/policy.yml")
+	assert.Contains(buf.String(),
+		"::notice file=.shipshape/policy.yml,line=1,title=b%2C the check::no location\n")
+}
+
+func TestSetOutputsLocationFile(t *testing.T) {
+	assert := assert.New(t)
+
+	outputs := []config.Output{{Format: "github"}, {Format: "gitlab-codequality", LocationFile: "ci.yml"}}
+	SetOutputsLocationFile(outputs, []string{"https://example.com/shipshape.yml", "local.yml"})
+	assert.Equal("local.yml", outputs[0].LocationFile)
+	assert.Equal("ci.yml", outputs[1].LocationFile)
+}
+
+func TestGitLabCodeQualityDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &result.ValueBreach{
+		CheckName: "a",
+		Severity:  "critical",
+		Value:     "Fail a",
+		Location:  &result.Location{File: "composer.json"},
+	}
+	RunResultList = result.NewResultList(false)
+	RunResultList.Results = []result.Result{
+		{Name: "a", Status: result.Fail, Breaches: []result.Breach{b}},
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	GitLabCodeQualityDisplay(w, "")
+	assert.JSONEq(`[{
+		"description": "Fail a",
+		"check_name": "a",
+		"fingerprint": "`+result.BreachFingerprint(b)+`",
+		"severity": "blocker",
+		"location": {"path": "composer.json", "lines": {"begin": 1}}
+	}]`, buf.String())
+}
+
+func TestJUnitTime(t *testing.T) {
+	assert := assert.New(t)
+
+	RunResultList = result.NewResultList(false)
+	RunResultList.Duration = 2 * time.Second
+	RunResultList.Results = []result.Result{
+		{Name: "a", CheckType: "test-check", Status: result.Pass, Duration: 1500 * time.Millisecond},
+		{Name: "b", CheckType: "test-check", Status: result.Pass, Duration: 250 * time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
 	JUnit(w)
-	assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
-<testsuites tests="0" errors="0">
-    <testsuite name="test-check" tests="0" errors="0">
-        <testcase name="a" classname="a"></testcase>
-        <testcase name="b" classname="b">
-            <error message="Fail b"></error>
-        </testcase>
-    </testsuite>
-</testsuites>
+	assert.Contains(buf.String(), `<testsuites tests="2" failures="0" errors="0" skipped="0" time="2">`)
+	assert.Contains(buf.String(), `<testsuite name="test-check" tests="2" failures="0" errors="0" skipped="0" time="1.75">`)
+	assert.Contains(buf.String(), `<testcase name="a" classname="a" time="1.5">`)
+	assert.Contains(buf.String(), `<testcase name="b" classname="b" time="0.25">`)
+}
+
+func TestNDJSONDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	RunResultList = result.ResultList{
+		TotalChecks:   1,
+		TotalBreaches: 1,
+		StartTime:     start,
+		Duration:      2 * time.Second,
+		Results: []result.Result{{
+			Name:      "a",
+			CheckType: "file",
+			Status:    result.Fail,
+			StartTime: start,
+			Duration:  time.Second,
+			Breaches:  []result.Breach{&result.ValueBreach{Value: "illegal file"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	NDJSONDisplay(&buf)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(lines, 3)
+	assert.Equal(`{"event":"run-started","time":"2023-01-01T00:00:00Z","totals":{"total-checks":1,"total-breaches":0}}`, lines[0])
+	assert.Contains(lines[1], `{"event":"check-finished","time":"2023-01-01T00:00:01Z","check-type":"file","check-name":"a","result":{`)
+	assert.Contains(lines[1], `"value":"illegal file"`)
+	assert.Equal(`{"event":"run-finished","time":"2023-01-01T00:00:02Z","totals":{"total-checks":1,"total-breaches":1,"status":"Fail","duration":2000000000}}`, lines[2])
+}
+
+func TestStreamOutputs(t *testing.T) {
+	assert := assert.New(t)
+	defer ResetEventHandlers()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "events", "events.ndjson")
+	var buf bytes.Buffer
+	remaining, closeStreams, err := StreamOutputs(&buf, []config.Output{
+		{Format: "ndjson", File: file},
+		{Format: "simple"},
+	})
+	assert.NoError(err)
+	assert.Equal([]config.Output{{Format: "simple"}}, remaining)
+
+	RunConfig = config.Config{}
+	RunResultList = result.NewResultList(false)
+	RunChecks()
+	assert.NoError(closeStreams())
+	assert.Empty(buf.String())
+
+	data, err := os.ReadFile(file)
+	assert.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(lines, 2)
+	assert.Contains(lines[0], `"event":"run-started"`)
+	assert.Contains(lines[1], `"event":"run-finished"`)
+}
+
+func TestProgress(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	p := NewProgress(&buf)
+	p.HandleEvent(Event{Type: EventRunStarted, Totals: &RunTotals{TotalChecks: 5}})
+	assert.Equal("\r\033[KChecks: 0/5 done, 0 running, 5 pending | Breaches: 0", buf.String())
+
+	for _, n := range []string{"e", "d", "c", "b", "a"} {
+		p.HandleEvent(Event{Type: EventCheckStarted, CheckName: n})
+	}
+	buf.Reset()
+	p.HandleEvent(Event{
+		Type:      EventCheckFinished,
+		CheckName: "e",
+		Result:    &result.Result{Breaches: []result.Breach{&result.ValueBreach{}, &result.ValueBreach{}}},
+	})
+	assert.Equal("\r\033[KChecks: 1/5 done, 4 running, 0 pending | Breaches: 2 | Running: a, b, c, +1 more", buf.String())
+
+	// Checks of different types can share a name.
+	p = NewProgress(&buf)
+	p.HandleEvent(Event{Type: EventRunStarted, Totals: &RunTotals{TotalChecks: 2}})
+	p.HandleEvent(Event{Type: EventCheckStarted, CheckType: "file", CheckName: "a"})
+	p.HandleEvent(Event{Type: EventCheckStarted, CheckType: "yaml", CheckName: "a"})
+	buf.Reset()
+	p.HandleEvent(Event{Type: EventCheckFinished, CheckType: "file", CheckName: "a"})
+	assert.Equal("\r\033[KChecks: 1/2 done, 1 running, 0 pending | Breaches: 0 | Running: a", buf.String())
+
+	buf.Reset()
+	p.HandleEvent(Event{Type: EventRunFinished, Totals: &RunTotals{}})
+	assert.Equal("\r\033[K", buf.String())
+}
+
+func TestComplianceDisplay(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("noControl", func(t *testing.T) {
+		RunResultList = result.ResultList{Results: []result.Result{{Name: "a"}}}
+		var buf bytes.Buffer
+		ComplianceDisplay(bufio.NewWriter(&buf))
+		assert.Equal("# Compliance report\n\n"+
+			"No control covered; add 'controls' to the checks to map them to a framework.\n",
+			buf.String())
+	})
+
+	t.Run("controls", func(t *testing.T) {
+		RunResultList = result.ResultList{Results: []result.Result{
+			{Name: "a", CheckType: "file", Status: result.Pass, Controls: []string{"ISM-1", "ISM-2"}},
+			{Name: "b", CheckType: "yaml", Status: result.Fail, Controls: []string{"ISM-2"},
+				Breaches: []result.Breach{&result.ValueBreach{}}},
+			{Name: "c", CheckType: "drupal-db-module", Status: result.Skip, Controls: []string{"E8-3"},
+				SkipReason: "database checks excluded"},
+			{Name: "d", CheckType: "file", Status: result.Pass},
+		}}
+		var buf bytes.Buffer
+		ComplianceDisplay(bufio.NewWriter(&buf))
+		assert.Equal(`# Compliance report
+
+Controls: 3 covered, 1 passing, 1 failing, 1 untested
+
+## E8-3: untested
+
+  - [Skip] c (drupal-db-module): database checks excluded
+
+## ISM-1: passing
+
+  - [Pass] a (file)
+
+## ISM-2: failing
+
+  - [Pass] a (file)
+  - [Fail] b (yaml): 1 breach(es)
+
+1 check(s) not mapped to any control.
 `, buf.String())
+	})
 }
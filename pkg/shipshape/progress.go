@@ -0,0 +1,93 @@
+package shipshape
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// progressMaxRunningNames is the number of running checks listed before the
+// remaining ones are summarised.
+const progressMaxRunningNames = 3
+
+// Progress renders a live, single-line view of the run on a terminal.
+type Progress struct {
+	w        io.Writer
+	lock     sync.Mutex
+	total    uint32
+	done     uint32
+	breaches int
+	// Names of the running checks, keyed by check type and name since checks
+	// of different types can share a name.
+	running map[string]string
+}
+
+// NewProgress creates a progress view writing to w.
+func NewProgress(w io.Writer) *Progress {
+	return &Progress{w: w, running: map[string]string{}}
+}
+
+// IsTerminal determines whether the file is an interactive terminal.
+func IsTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// HandleEvent updates the progress view; it is meant to be registered with
+// AddEventHandler.
+func (p *Progress) HandleEvent(e Event) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch e.Type {
+	case EventRunStarted:
+		// A batch run starts a new run for each project.
+		p.total = e.Totals.TotalChecks
+		p.done = 0
+		p.breaches = 0
+	case EventCheckStarted:
+		p.running[e.CheckType+"\x1e"+e.CheckName] = e.CheckName
+	case EventCheckFinished:
+		delete(p.running, e.CheckType+"\x1e"+e.CheckName)
+		p.done++
+		if e.Result != nil {
+			p.breaches += len(e.Result.Breaches)
+		}
+	case EventRunFinished:
+		// Clear the line so that the report starts on a clean one.
+		fmt.Fprint(p.w, "\r\033[K")
+		return
+	default:
+		return
+	}
+	fmt.Fprint(p.w, "\r\033[K"+p.line())
+}
+
+func (p *Progress) line() string {
+	pending := int(p.total) - int(p.done) - len(p.running)
+	if pending < 0 {
+		pending = 0
+	}
+	line := fmt.Sprintf("Checks: %d/%d done, %d running, %d pending | Breaches: %d",
+		p.done, p.total, len(p.running), pending, p.breaches)
+	if len(p.running) == 0 {
+		return line
+	}
+
+	names := []string{}
+	for _, n := range p.running {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	if len(names) > progressMaxRunningNames {
+		names = append(names[:progressMaxRunningNames],
+			fmt.Sprintf("+%d more", len(p.running)-progressMaxRunningNames))
+	}
+	return line + " | Running: " + strings.Join(names, ", ")
+}
@@ -0,0 +1,115 @@
+package shipshape
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/salsadigitalauorg/shipshape/pkg/config"
+	"github.com/salsadigitalauorg/shipshape/pkg/result"
+)
+
+// fileDiffCheckType is the type of the check generating diffs, for which the
+// reports render the diff instead of the raw breach value.
+const fileDiffCheckType = "filediff"
+
+// fileDiffPrefix is the prefix of a FileDiffCheck breach value.
+const fileDiffPrefix = "diff: \n"
+
+// countEntry is a label/count pair used to render totals in a stable order.
+type countEntry struct {
+	Label string
+	Count int
+}
+
+// reportSummary holds the totals displayed at the top of the markdown & html
+// reports.
+type reportSummary struct {
+	TotalChecks          uint32
+	Passed               int
+	Failed               int
+	Skipped              int
+	TotalBreaches        uint32
+	BySeverity           []countEntry
+	ByType               []countEntry
+	RemediationPerformed bool
+	RemediationTotals    []countEntry
+}
+
+// reportBreach is a breach broken down into parts that can be rendered as
+// nested lists or code blocks.
+type reportBreach struct {
+	Title  string
+	Values []string
+	Diff   string
+}
+
+func newReportSummary(rl *result.ResultList) reportSummary {
+	s := reportSummary{
+		TotalChecks:          rl.TotalChecks,
+		TotalBreaches:        rl.TotalBreaches,
+		RemediationPerformed: rl.RemediationPerformed,
+	}
+	for _, r := range rl.Results {
+		switch r.Status {
+		case result.Fail:
+			s.Failed++
+		case result.Skip:
+			s.Skipped++
+		default:
+			s.Passed++
+		}
+	}
+	if s.TotalChecks == 0 {
+		s.TotalChecks = uint32(len(rl.Results))
+	}
+
+	// Highest severity first, followed by any unknown severity.
+	for i := len(config.Severities) - 1; i >= 0; i-- {
+		sv := string(config.Severities[i])
+		s.BySeverity = append(s.BySeverity, countEntry{sv, rl.BreachCountBySeverity[sv]})
+	}
+	for _, sv := range sortedKeys(rl.BreachCountBySeverity) {
+		if !config.IsValidSeverity(config.Severity(sv)) {
+			s.BySeverity = append(s.BySeverity, countEntry{sv, rl.BreachCountBySeverity[sv]})
+		}
+	}
+
+	for _, ct := range sortedKeys(rl.BreachCountByType) {
+		s.ByType = append(s.ByType, countEntry{ct, rl.BreachCountByType[ct]})
+	}
+
+	for _, k := range []string{"successful", "partial", "failed", "unsupported"} {
+		s.RemediationTotals = append(s.RemediationTotals,
+			countEntry{k, int(rl.RemediationTotals[k])})
+	}
+	return s
+}
+
+func newReportBreach(r result.Result, b result.Breach) reportBreach {
+	if r.CheckType == fileDiffCheckType {
+		if value := result.BreachGetValue(b); strings.HasPrefix(value, fileDiffPrefix) {
+			return reportBreach{
+				Title: result.BreachGetValueLabel(b),
+				Diff:  strings.TrimPrefix(value, fileDiffPrefix),
+			}
+		}
+	}
+
+	if values := result.BreachGetValues(b); len(values) > 0 {
+		title := result.BreachGetKey(b)
+		if kl, vl := result.BreachGetKeyLabel(b), result.BreachGetValueLabel(b); kl != "" && vl != "" {
+			title = "[" + kl + ":" + title + "] " + vl
+		}
+		return reportBreach{Title: title, Values: values}
+	}
+	return reportBreach{Title: b.String()}
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -3,13 +3,21 @@
 package shipshape
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/lagoon"
+	"github.com/salsadigitalauorg/shipshape/pkg/plugin"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
 	"github.com/salsadigitalauorg/shipshape/pkg/utils"
 
@@ -17,9 +25,16 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// Environment selects the severity overrides to apply to the checks.
+var Environment string
+
 var RunConfig config.Config
+
+// RunConfigHash is the sha256 of the config data the run was initialised
+// with, identifying the set of policies applied.
+var RunConfigHash string
 var RunResultList result.ResultList
-var OutputFormats = []string{"json", "junit", "simple", "table"}
+var OutputFormats = []string{"compliance", "github", "gitlab-codequality", "html", "json", "junit", "markdown", "ndjson", "simple", "table", "template"}
 
 func Init(projectDir string, configFiles []string, checkTypesToRun []string, excludeDb bool, remediate bool, logLevel string, lagoonApiBaseUrl string, lagoonApiToken string) error {
 	if logLevel == "" {
@@ -56,25 +71,39 @@ func Init(projectDir string, configFiles []string, checkTypesToRun []string, exc
 	log.WithFields(log.Fields{
 		"ProjectDir":    RunConfig.ProjectDir,
 		"FailSeverity":  RunConfig.FailSeverity,
+		"Environment":   Environment,
 		"Remediate":     RunConfig.Remediate,
 		"RunResultList": fmt.Sprintf("%+v", RunResultList),
 	}).Debug("basic config")
 
-	log.Print("initialising checks")
+	return prepareChecks(&RunConfig, "", Environment, remediate,
+		checkTypesToRun, excludeDb, log.StandardLogger())
+}
+
+// prepareChecks applies the severity overrides, initialises the checks of
+// the config and filters the ones to run. The checks use the global
+// ProjectDir when projectDir is empty.
+func prepareChecks(cfg *config.Config, projectDir string, env string, remediate bool, checkTypesToRun []string, excludeDb bool, logger log.FieldLogger) error {
+	if err := cfg.ApplySeverityOverrides(env); err != nil {
+		return err
+	}
+
+	logger.Print("initialising checks")
 	var checksCount int
-	for ct, checks := range RunConfig.Checks {
+	for ct, checks := range cfg.Checks {
 		for _, c := range checks {
 			c.Init(ct)
 			c.SetPerformRemediation(remediate)
+			c.SetProjectDir(projectDir)
 			checksCount++
 		}
 	}
 
-	log.Print("filtering checks")
-	RunConfig.FilterChecksToRun(checkTypesToRun, excludeDb)
-	log.WithField("checksCount", checksCount).Print("checks filtered")
-	jsonChecks, _ := json.Marshal(RunConfig.Checks)
-	log.WithFields(log.Fields{
+	logger.Print("filtering checks")
+	cfg.FilterChecksToRun(checkTypesToRun, excludeDb)
+	logger.WithField("checksCount", checksCount).Print("checks filtered")
+	jsonChecks, _ := json.Marshal(cfg.Checks)
+	logger.WithFields(log.Fields{
 		"Checks": string(jsonChecks),
 	}).Debug("checks initialised and filtered")
 
@@ -86,24 +115,34 @@ func ReadAndParseConfig(projectDir string, files []string) error {
 	if err != nil {
 		return err
 	}
+	hash := sha256.New()
+	for _, data := range configData {
+		hash.Write(data)
+	}
+	RunConfigHash = hex.EncodeToString(hash.Sum(nil))
+
 	err = ParseConfigData(configData)
 	if err != nil {
 		return err
 	}
 
-	if RunConfig.ProjectDir == "" && projectDir != "" {
-		RunConfig.ProjectDir = projectDir
-	} else {
-		// Default project directory is current directory.
-		projectDir, _ = os.Getwd()
-		RunConfig.ProjectDir = projectDir
-	}
+	setConfigDefaults(&RunConfig, projectDir)
+	return nil
+}
 
-	if RunConfig.FailSeverity == "" {
-		RunConfig.FailSeverity = config.HighSeverity
+// setConfigDefaults applies the project directory provided, which takes
+// precedence over the config's and defaults to the current directory, as
+// well as the default fail severity.
+func setConfigDefaults(cfg *config.Config, projectDir string) {
+	if projectDir != "" {
+		cfg.ProjectDir = projectDir
+	} else if cfg.ProjectDir == "" {
+		cfg.ProjectDir, _ = os.Getwd()
 	}
 
-	return nil
+	if cfg.FailSeverity == "" {
+		cfg.FailSeverity = config.HighSeverity
+	}
 }
 
 func FetchConfigData(files []string) ([][]byte, error) {
@@ -133,79 +172,319 @@ func FetchConfigData(files []string) ([][]byte, error) {
 }
 
 func ParseConfigData(configData [][]byte) error {
+	cfg, err := parseConfigData(configData, config.ChecksRegistry, log.StandardLogger())
+	if err != nil {
+		return err
+	}
+	RunConfig = cfg
+	return nil
+}
+
+// parseConfigData parses and merges the config data, creating the checks from
+// the registry provided.
+func parseConfigData(configData [][]byte, registry *config.Registry, logger log.FieldLogger) (config.Config, error) {
+	if err := registerPlugins(configData, registry, logger); err != nil {
+		return config.Config{}, err
+	}
+
 	finalCfg := config.Config{}
 	for i, data := range configData {
-		log.Print("parsing config")
-		cfg := config.Config{}
-		if err := yaml.Unmarshal(data, &cfg); err != nil {
-			log.WithError(err).Error("could not parse config")
-			return err
+		logger.Print("parsing config")
+		cfg, err := config.ParseConfig(data, registry)
+		if err != nil {
+			logger.WithError(err).Error("could not parse config")
+			return config.Config{}, err
 		}
 
 		if i == 0 {
 			finalCfg = cfg
-			if len(configData) == 1 {
-				RunConfig = finalCfg
-				return nil
-			}
 			continue
 		}
 
-		log.Print("merging into final config")
+		logger.Print("merging into final config")
 		if err := finalCfg.Merge(cfg); err != nil {
-			log.WithError(err).Error("could not merge config")
-			panic(err)
+			logger.WithError(err).Error("could not merge config")
+			return config.Config{}, err
+		}
+	}
+	return finalCfg, nil
+}
+
+// RegisterConfigPlugins adds the check types of the plugins defined in the
+// config files to the registry, e.g. to list them.
+func RegisterConfigPlugins(files []string) error {
+	configData, err := FetchConfigData(files)
+	if err != nil {
+		return err
+	}
+	return registerPlugins(configData, config.ChecksRegistry, log.StandardLogger())
+}
+
+// registerPlugins adds the check types of the plugins defined in the config
+// data to the registry, before the checks using them are parsed.
+func registerPlugins(configData [][]byte, registry *config.Registry, logger log.FieldLogger) error {
+	pluginsCfg := config.Config{}
+	for _, data := range configData {
+		cfg := struct {
+			Plugins    []config.Plugin `yaml:"plugins"`
+			PluginsDir string          `yaml:"plugins-dir"`
+		}{}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return err
+		}
+		err := pluginsCfg.Merge(config.Config{Plugins: cfg.Plugins, PluginsDir: cfg.PluginsDir})
+		if err != nil {
+			return err
+		}
+	}
+
+	if pluginsCfg.PluginsDir != "" {
+		logger.WithField("dir", pluginsCfg.PluginsDir).Print("registering plugins from directory")
+		if err := plugin.RegisterDir(registry, pluginsCfg.PluginsDir); err != nil {
+			return fmt.Errorf("could not register plugins: %w", err)
+		}
+	}
+	for _, p := range pluginsCfg.Plugins {
+		logger.WithField("type", p.Type).Print("registering plugin")
+		if err := plugin.Register(registry, p); err != nil {
+			return fmt.Errorf("could not register plugin: %w", err)
 		}
 	}
-	RunConfig = finalCfg
 	return nil
 }
 
 func RunChecks() {
-	log.Print("preparing concurrent check runs")
-	var wg sync.WaitGroup
-	for ct, checks := range RunConfig.Checks {
-		checks := checks
-		RunResultList.IncrChecks(string(ct), len(checks))
-		for i := range checks {
+	runChecks(context.Background(), &RunConfig, &RunResultList, log.StandardLogger(), publishEvent)
+}
+
+// runChecks runs the checks of the config concurrently, adding their results
+// to the list; checks not started yet when the context is cancelled are
+// reported as skipped. Checks implementing config.ResultsConsumer are run
+// after the others, and after the consumers they depend on.
+func runChecks(ctx context.Context, cfg *config.Config, rl *result.ResultList, logger log.FieldLogger, publish func(Event)) {
+	logger.Print("preparing concurrent check runs")
+	rl.StartTime = time.Now()
+	for c, reason := range cfg.SkippedChecks {
+		rl.AddResult(skippedResult(c, reason))
+	}
+	for ct, checks := range cfg.Checks {
+		rl.IncrChecks(string(ct), len(checks))
+	}
+	publish(Event{
+		Type:   EventRunStarted,
+		Time:   rl.StartTime,
+		Totals: &RunTotals{TotalChecks: rl.TotalChecks},
+	})
+
+	run := func(checks []config.Check) {
+		var wg sync.WaitGroup
+		for _, check := range checks {
 			wg.Add(1)
-			check := checks[i]
-			go func() {
+			go func(check config.Check) {
 				defer wg.Done()
-				ProcessCheck(&RunResultList, check)
-			}()
+				if ctx.Err() != nil {
+					rl.AddResult(skippedResult(check, "run cancelled"))
+					return
+				}
+				processCheck(rl, check, logger, publish)
+			}(check)
+		}
+		wg.Wait()
+	}
+
+	// Checks using the results of other checks run once those have
+	// completed.
+	checks, consumers := []config.Check{}, []config.Check{}
+	pending := map[string]bool{}
+	for _, cs := range cfg.Checks {
+		for _, check := range cs {
+			if _, ok := check.(config.ResultsConsumer); ok {
+				consumers = append(consumers, check)
+				pending[check.GetName()] = true
+				continue
+			}
+			checks = append(checks, check)
+		}
+	}
+	run(checks)
+	for len(consumers) > 0 {
+		ready, waiting := []config.Check{}, []config.Check{}
+		for _, check := range consumers {
+			if dependsOnPending(check.(config.ResultsConsumer), pending) {
+				waiting = append(waiting, check)
+				continue
+			}
+			ready = append(ready, check)
+		}
+		// Circular references are run as they are, reporting the results
+		// they are missing.
+		if len(ready) == 0 {
+			ready, waiting = waiting, nil
+		}
+
+		results := append([]result.Result{}, rl.Results...)
+		for _, check := range ready {
+			check.(config.ResultsConsumer).SetResults(results)
+			delete(pending, check.GetName())
+		}
+		run(ready)
+		consumers = waiting
+	}
+	rl.Sort()
+	rl.RemediationTotalsCount()
+	rl.Duration = time.Since(rl.StartTime)
+	rl.Policy = cfg.EvaluateFailPolicy(rl)
+	publish(Event{Type: EventRunFinished, Totals: newRunTotals(rl)})
+}
+
+// dependsOnPending returns true if the consumer uses the results of checks
+// which have not run yet.
+func dependsOnPending(rc config.ResultsConsumer, pending map[string]bool) bool {
+	for _, name := range rc.ResultsDependencies() {
+		if pending[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func skippedResult(c config.Check, reason string) result.Result {
+	r := *c.GetResult()
+	r.Name = c.GetName()
+	r.Severity = string(c.GetSeverity())
+	r.CheckType = string(c.GetType())
+	r.Status = result.Skip
+	r.SkipReason = reason
+	return r
+}
+
+// ExpandProjects resolves the glob patterns of the projects config into the
+// list of project directories.
+func ExpandProjects(patterns []string) ([]string, error) {
+	projects := []string{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project pattern '%s': %w", pattern, err)
+		}
+		sort.Strings(matches)
+		for _, m := range matches {
+			if isDir, _ := utils.IsDirectory(m); !isDir {
+				continue
+			}
+			if !utils.StringSliceContains(projects, m) {
+				projects = append(projects, m)
+			}
+		}
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("no project directory matching '%s'", strings.Join(patterns, ", "))
+	}
+	return projects, nil
+}
+
+// RunProjects runs the checks against each project in turn. initFn is
+// expected to initialise the config for the given project so that each run
+// gets fresh checks; the results are then combined in RunResultList, labelled
+// with their project. The events of each run are labelled with their project,
+// followed by a run-finished event for the combined results.
+func RunProjects(projects []string, initFn func(projectDir string) error) error {
+	lists := []result.ResultList{}
+	for _, p := range projects {
+		log.WithField("project", p).Print("running checks for project")
+		if err := initFn(p); err != nil {
+			return fmt.Errorf("project '%s': %w", p, err)
+		}
+		publish := func(e Event) {
+			e.Project = p
+			publishEvent(e)
 		}
+		runChecks(context.Background(), &RunConfig, &RunResultList, log.StandardLogger(), publish)
+		lists = append(lists, RunResultList)
 	}
-	wg.Wait()
-	RunResultList.Sort()
-	RunResultList.RemediationTotalsCount()
+	RunResultList = result.Merge(projects, lists)
+	RunResultList.Policy = RunConfig.EvaluateFailPolicy(&RunResultList)
+	publishEvent(Event{Type: EventRunFinished, Totals: newRunTotals(&RunResultList)})
+	return nil
 }
 
 func ProcessCheck(rl *result.ResultList, c config.Check) {
-	contextLogger := log.WithFields(log.Fields{
+	processCheck(rl, c, log.StandardLogger(), publishEvent)
+}
+
+func processCheck(rl *result.ResultList, c config.Check, logger log.FieldLogger, publish func(Event)) {
+	contextLogger := logger.WithFields(log.Fields{
 		"check-type": c.GetType(),
 		"check-name": c.GetName(),
 	})
 	contextLogger.Print("processing check")
-	if c.RequiresData() {
-		contextLogger.Print("fetching data")
-		c.FetchData()
-		c.HasData(true)
-		if len(c.GetResult().Breaches) == 0 {
-			c.UnmarshalDataMap()
-		}
-	}
-	if len(c.GetResult().Breaches) == 0 && len(c.GetResult().Passes) == 0 {
-		contextLogger.Print("running check")
-		c.RunCheck()
-	}
-	if len(c.GetResult().Breaches) > 0 && c.ShouldPerformRemediation() {
-		contextLogger.Print("performing remediation")
-		c.Remediate()
+	start := time.Now()
+	publish(Event{
+		Type:      EventCheckStarted,
+		Time:      start,
+		CheckType: string(c.GetType()),
+		CheckName: c.GetName(),
+	})
+	timings := result.Timings{}
+	remediated := false
+	timePhase := func(d *time.Duration, phase func()) {
+		phaseStart := time.Now()
+		phase()
+		*d = time.Since(phaseStart)
 	}
+
+	func() {
+		// A failing check should not bring the whole run down; report
+		// the problem as an error on the check instead.
+		defer func() {
+			if r := recover(); r != nil {
+				contextLogger.WithField("panic", r).Error("check panicked")
+				c.AddError(fmt.Sprintf("check panicked: %v", r))
+			}
+		}()
+
+		if c.RequiresData() {
+			contextLogger.Print("fetching data")
+			timePhase(&timings.FetchData, func() {
+				c.FetchData()
+				c.HasData(true)
+			})
+			if len(c.GetResult().Breaches) == 0 && len(c.GetResult().Errors) == 0 {
+				timePhase(&timings.UnmarshalDataMap, c.UnmarshalDataMap)
+			}
+		}
+		if len(c.GetResult().Breaches) == 0 && len(c.GetResult().Errors) == 0 &&
+			len(c.GetResult().Passes) == 0 {
+			contextLogger.Print("running check")
+			timePhase(&timings.RunCheck, c.RunCheck)
+		}
+		if len(c.GetResult().Breaches) > 0 && c.ShouldPerformRemediation() {
+			contextLogger.Print("performing remediation")
+			remediated = true
+			timePhase(&timings.Remediate, c.Remediate)
+		}
+	}()
 	c.GetResult().DetermineResultStatus(c.ShouldPerformRemediation())
+	c.GetResult().StartTime = start
+	c.GetResult().Duration = time.Since(start)
+	c.GetResult().Timings = timings
+	if remediated {
+		publish(Event{
+			Type:              EventRemediationPerformed,
+			CheckType:         string(c.GetType()),
+			CheckName:         c.GetName(),
+			RemediationStatus: c.GetResult().RemediationStatus,
+		})
+	}
 	contextLogger.
 		WithFields(log.Fields{"result": c.GetResult()}).
 		Print("check processed")
 	rl.AddResult(*c.GetResult())
+	r := *c.GetResult()
+	publish(Event{
+		Type:      EventCheckFinished,
+		CheckType: string(c.GetType()),
+		CheckName: c.GetName(),
+		Result:    &r,
+	})
 }
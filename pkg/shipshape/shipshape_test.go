@@ -1,9 +1,13 @@
 package shipshape_test
 
 import (
+	"errors"
 	"io"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 	"github.com/salsadigitalauorg/shipshape/pkg/result"
@@ -161,6 +165,20 @@ func TestRunChecks(t *testing.T) {
 		string(testchecks.TestCheck1): 1,
 		string(testchecks.TestCheck2): 1,
 	}, RunResultList.BreachCountByType)
+	assert.False(RunResultList.StartTime.IsZero())
+	assert.Greater(RunResultList.Duration, time.Duration(0))
+
+	// Timings vary between runs; ensure they are set then reset them for
+	// the comparison below.
+	for i := range RunResultList.Results {
+		r := &RunResultList.Results[i]
+		assert.False(r.StartTime.IsZero())
+		assert.Greater(r.Duration, time.Duration(0))
+		assert.Greater(r.Timings.FetchData, time.Duration(0))
+		r.StartTime = time.Time{}
+		r.Duration = 0
+		r.Timings = result.Timings{}
+	}
 	assert.ElementsMatch([]result.Result{
 		{
 			Name:      "test1stcheck",
@@ -194,3 +212,209 @@ func TestRunChecks(t *testing.T) {
 		}},
 		RunResultList.Results)
 }
+
+type panickingCheck struct{ config.CheckBase }
+
+func (c *panickingCheck) RequiresData() bool { return false }
+
+func (c *panickingCheck) RunCheck() { panic("oops") }
+
+func TestRunChecksSkippedAndErrors(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	assert := assert.New(t)
+
+	panicking := &panickingCheck{CheckBase: config.CheckBase{Name: "panicking"}}
+	panicking.Init("panicking-check")
+	skipped := &testchecks.TestCheck1Check{CheckBase: config.CheckBase{Name: "skipped"}}
+	skipped.Init(testchecks.TestCheck1)
+	RunConfig = config.Config{
+		Checks:        config.CheckMap{"panicking-check": {panicking}},
+		SkippedChecks: map[config.Check]string{skipped: "database checks excluded"},
+	}
+
+	RunResultList = result.NewResultList(false)
+	RunChecks()
+	assert.Equal(uint32(1), RunResultList.TotalChecks)
+	assert.Len(RunResultList.Results, 2)
+	assert.Equal(result.Fail, RunResultList.Results[0].Status)
+	assert.Equal([]string{"check panicked: oops"}, RunResultList.Results[0].Errors)
+	assert.Equal(result.Skip, RunResultList.Results[1].Status)
+	assert.Equal("database checks excluded", RunResultList.Results[1].SkipReason)
+	assert.Equal(result.Fail, RunResultList.Status())
+}
+
+func TestRunChecksEvents(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	assert := assert.New(t)
+
+	test1stCheck := &testchecks.TestCheck1Check{CheckBase: config.CheckBase{Name: "test1stcheck"}}
+	test1stCheck.Init(testchecks.TestCheck1)
+	test2ndCheck := &testchecks.TestCheck2Check{CheckBase: config.CheckBase{Name: "test2ndcheck"}}
+	test2ndCheck.Init(testchecks.TestCheck2)
+	RunConfig = config.Config{
+		Checks: config.CheckMap{
+			testchecks.TestCheck1: {test1stCheck},
+			testchecks.TestCheck2: {test2ndCheck},
+		},
+	}
+
+	lock := sync.Mutex{}
+	events := []Event{}
+	defer ResetEventHandlers()
+	AddEventHandler(func(e Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, e)
+	})
+
+	RunResultList = result.NewResultList(false)
+	RunChecks()
+
+	assert.Len(events, 6)
+	assert.Equal(EventRunStarted, events[0].Type)
+	assert.Equal(uint32(2), events[0].Totals.TotalChecks)
+
+	started := map[string]bool{}
+	for _, e := range events[1:5] {
+		assert.False(e.Time.IsZero())
+		switch e.Type {
+		case EventCheckStarted:
+			started[e.CheckName] = true
+		case EventCheckFinished:
+			assert.True(started[e.CheckName], "check %s finished before starting", e.CheckName)
+			assert.Equal(e.CheckName, e.Result.Name)
+			assert.Equal(result.Fail, e.Result.Status)
+		default:
+			t.Errorf("unexpected event %s", e.Type)
+		}
+	}
+	assert.Len(started, 2)
+
+	last := events[5]
+	assert.Equal(EventRunFinished, last.Type)
+	assert.Equal(uint32(2), last.Totals.TotalChecks)
+	assert.Equal(uint32(2), last.Totals.TotalBreaches)
+	assert.Equal(result.Fail, last.Totals.Status)
+	assert.Equal(RunResultList.Duration, last.Totals.Duration)
+}
+
+func TestRunChecksRemediationEvents(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	assert := assert.New(t)
+
+	// The remediation of the test checks is near instant.
+	check := &testchecks.TestCheck1Check{CheckBase: config.CheckBase{
+		Name: "test1stcheck", PerformRemediation: true}}
+	check.Init(testchecks.TestCheck1)
+	RunConfig = config.Config{
+		Checks: config.CheckMap{testchecks.TestCheck1: {check}},
+	}
+
+	lock := sync.Mutex{}
+	remediated := []string{}
+	defer ResetEventHandlers()
+	AddEventHandler(func(e Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		if e.Type == EventRemediationPerformed {
+			remediated = append(remediated, e.CheckName)
+		}
+	})
+
+	RunResultList = result.NewResultList(true)
+	RunChecks()
+	assert.Equal([]string{"test1stcheck"}, remediated)
+}
+
+func TestExpandProjects(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	for _, d := range []string{"site-b", "site-a", "other"} {
+		assert.NoError(os.Mkdir(filepath.Join(dir, d), 0755))
+	}
+	assert.NoError(os.WriteFile(filepath.Join(dir, "site-file"), []byte{}, 0644))
+
+	projects, err := ExpandProjects([]string{
+		filepath.Join(dir, "site-*"),
+		filepath.Join(dir, "site-a"),
+	})
+	assert.NoError(err)
+	assert.Equal([]string{filepath.Join(dir, "site-a"), filepath.Join(dir, "site-b")}, projects)
+
+	_, err = ExpandProjects([]string{filepath.Join(dir, "none-*")})
+	assert.ErrorContains(err, "no project directory matching")
+}
+
+func TestRunProjects(t *testing.T) {
+	currLogOut := logrus.StandardLogger().Out
+	defer logrus.SetOutput(currLogOut)
+	logrus.SetOutput(io.Discard)
+
+	assert := assert.New(t)
+
+	ResetEventHandlers()
+	defer ResetEventHandlers()
+	events := []Event{}
+	lock := sync.Mutex{}
+	AddEventHandler(func(e Event) {
+		lock.Lock()
+		defer lock.Unlock()
+		events = append(events, e)
+	})
+
+	initialised := []string{}
+	err := RunProjects([]string{"site-a", "site-b"}, func(projectDir string) error {
+		initialised = append(initialised, projectDir)
+		c := &testchecks.TestCheck1Check{CheckBase: config.CheckBase{Name: "test1stcheck"}}
+		c.Init(testchecks.TestCheck1)
+		RunConfig = config.Config{
+			ProjectDir:   projectDir,
+			FailSeverity: config.HighSeverity,
+			Checks:       config.CheckMap{testchecks.TestCheck1: {c}},
+		}
+		RunResultList = result.NewResultList(false)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"site-a", "site-b"}, initialised)
+	assert.Equal(uint32(2), RunResultList.TotalChecks)
+	assert.Equal(uint32(2), RunResultList.TotalBreaches)
+	if assert.Len(RunResultList.Results, 2) {
+		assert.Equal("[site-a] test1stcheck", RunResultList.Results[0].DisplayName())
+		assert.Equal("[site-b] test1stcheck", RunResultList.Results[1].DisplayName())
+	}
+	assert.Len(RunResultList.Projects, 2)
+	assert.NotNil(RunResultList.Policy)
+
+	if assert.Len(events, 9) {
+		for _, e := range events[:4] {
+			assert.Equal("site-a", e.Project)
+		}
+		for _, e := range events[4:8] {
+			assert.Equal("site-b", e.Project)
+		}
+		assert.Equal(EventRunFinished, events[3].Type)
+		assert.Equal(uint32(1), events[3].Totals.TotalChecks)
+		last := events[8]
+		assert.Equal(EventRunFinished, last.Type)
+		assert.Empty(last.Project)
+		assert.Equal(uint32(2), last.Totals.TotalChecks)
+		assert.Equal(uint32(2), last.Totals.TotalBreaches)
+		assert.NotNil(last.Totals.Policy)
+	}
+
+	err = RunProjects([]string{"site-a"}, func(string) error {
+		return errors.New("invalid config")
+	})
+	assert.EqualError(err, "project 'site-a': invalid config")
+}
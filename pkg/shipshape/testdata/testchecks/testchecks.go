@@ -1,6 +1,8 @@
 package testchecks
 
 import (
+	"sync"
+
 	"github.com/salsadigitalauorg/shipshape/pkg/config"
 )
 
@@ -17,7 +19,13 @@ type TestCheck2Check struct {
 	Bar              string `yaml:"bar"`
 }
 
+var registerOnce sync.Once
+
+// RegisterChecks adds the test check types to the registry; it can be called
+// by multiple tests.
 func RegisterChecks() {
-	config.ChecksRegistry[TestCheck1] = func() config.Check { return &TestCheck1Check{} }
-	config.ChecksRegistry[TestCheck2] = func() config.Check { return &TestCheck2Check{} }
+	registerOnce.Do(func() {
+		config.MustRegister(TestCheck1, func() config.Check { return &TestCheck1Check{} }, config.Meta{})
+		config.MustRegister(TestCheck2, func() config.Check { return &TestCheck2Check{} }, config.Meta{})
+	})
 }
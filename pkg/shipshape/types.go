@@ -4,30 +4,60 @@ import (
 	"encoding/xml"
 )
 
+// JUnitFailure is a policy breach.
+type JUnitFailure struct {
+	XMLName xml.Name `xml:"failure"`
+	Message string   `xml:"message,attr"`
+	Type    string   `xml:"type,attr,omitempty"`
+}
+
+// JUnitError is a problem encountered while executing a check.
 type JUnitError struct {
 	XMLName xml.Name `xml:"error"`
 	Message string   `xml:"message,attr"`
 }
 
+type JUnitSkipped struct {
+	XMLName xml.Name `xml:"skipped"`
+	Message string   `xml:"message,attr,omitempty"`
+}
+
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
 type JUnitTestCase struct {
 	XMLName   xml.Name `xml:"testcase"`
 	Name      string   `xml:"name,attr"`
 	ClassName string   `xml:"classname,attr"`
-	Errors    []JUnitError
+	// Time taken to run the check, in seconds.
+	Time       float64         `xml:"time,attr"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	Skipped    *JUnitSkipped
+	Failures   []JUnitFailure
+	Errors     []JUnitError
+	SystemOut  string `xml:"system-out,omitempty"`
 }
 
 type JUnitTestSuite struct {
 	XMLName   xml.Name `xml:"testsuite"`
 	Name      string   `xml:"name,attr"`
 	Tests     int      `xml:"tests,attr"`
+	Failures  int      `xml:"failures,attr"`
 	Errors    int      `xml:"errors,attr"`
+	Skipped   int      `xml:"skipped,attr"`
+	Time      float64  `xml:"time,attr"`
 	TestCases []JUnitTestCase
 }
 
 // JUnit format taken from https://llg.cubic.org/docs/junit/.
 type JUnitTestSuites struct {
 	XMLName    xml.Name `xml:"testsuites"`
-	Tests      uint32   `xml:"tests,attr"`
-	Errors     uint32   `xml:"errors,attr"`
+	Tests      int      `xml:"tests,attr"`
+	Failures   int      `xml:"failures,attr"`
+	Errors     int      `xml:"errors,attr"`
+	Skipped    int      `xml:"skipped,attr"`
+	Time       float64  `xml:"time,attr"`
 	TestSuites []JUnitTestSuite
 }